@@ -1,40 +1,14 @@
+// Package benchmarks measures the public building blocks of a lookup. Benchmarks of the
+// lookup itself are in src/internal/service, as packages outside src cannot import src/internal.
 package benchmarks
 
 import (
 	"testing"
 
-	"github.com/baditaflorin/country-iso-matcher/internal/repository/memory"
-	"github.com/baditaflorin/country-iso-matcher/internal/service"
-	"github.com/baditaflorin/country-iso-matcher/pkg/normalizer"
+	"country-iso-matcher/src/pkg/fuzzy"
+	"country-iso-matcher/src/pkg/normalizer"
 )
 
-func BenchmarkCountryLookup(b *testing.B) {
-	// Setup
-	normalizer := normalizer.NewTextNormalizer()
-	repo := memory.NewCountryRepository(normalizer)
-	service := service.NewCountryService(repo)
-
-	countries := []string{
-		"Romania",
-		"Germany",
-		"United States",
-		"France",
-		"italy",
-		"SPAIN",
-		"united kingdom",
-	}
-
-	b.ResetTimer()
-
-	for i := 0; i < b.N; i++ {
-		country := countries[i%len(countries)]
-		_, err := service.LookupCountry(country)
-		if err != nil {
-			b.Errorf("unexpected error: %v", err)
-		}
-	}
-}
-
 func BenchmarkNormalizer(b *testing.B) {
 	normalizer := normalizer.NewTextNormalizer()
 
@@ -53,3 +27,19 @@ func BenchmarkNormalizer(b *testing.B) {
 		_ = normalizer.Normalize(input)
 	}
 }
+
+func BenchmarkFuzzyDistance(b *testing.B) {
+	pairs := [][2]string{
+		{"germnay", "germany"},
+		{"untied states", "united states"},
+		{"frnace", "france"},
+		{"rumania", "romania"},
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		pair := pairs[i%len(pairs)]
+		_ = fuzzy.Distance(pair[0], pair[1])
+	}
+}
@@ -0,0 +1,470 @@
+// Command gendata generates the embedded reference dataset (src/internal/data/embedded/countries.json).
+//
+// ISO codes and the names in all supported languages come from the CLDR data in golang.org/x/text,
+// the other code systems (IOC, FIFA, ITU, FIPS, TLD, vehicle) from data/codes.csv, the
+// metadata (continent, UN region, capital, currencies, languages) from data/meta.csv and
+// the demonyms from data/demonyms.csv.
+// The curated English names and aliases of the memory source, data/countries.csv,
+// data/aliases.csv and data/countries/*.json are layered on top, so names and aliases
+// edited there end up in the embedded dataset the next time it is generated.
+// The withdrawn countries of data/historical.json (ISO 3166-3), the subdivisions of
+// data/subdivisions.csv (ISO 3166-2) and the postal code formats of data/postal_codes.csv
+// are checked against the dataset and written to src/internal/data/embedded/historical.json,
+// subdivisions.json and postal_codes.json:
+//
+//	go generate ./src/internal/data
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+
+	"country-iso-matcher/src/internal/data"
+	"country-iso-matcher/src/internal/domain"
+)
+
+// languages are the name languages of the dataset, the same as data/countries/*.json
+var languages = []string{"en", "es", "fr", "de", "zh", "ja", "ar", "ru", "pt", "it"}
+
+// withdrawn are former ISO 3166-1 codes CLDR still knows as regions
+var withdrawn = map[string]bool{
+	"AN": true, "BU": true, "CS": true, "CT": true, "DD": true, "DY": true, "FX": true,
+	"HV": true, "JT": true, "MI": true, "NH": true, "NQ": true, "NT": true, "PU": true,
+	"PZ": true, "RH": true, "SU": true, "TP": true, "UK": true, "VD": true, "WK": true,
+	"YD": true, "YU": true, "ZR": true,
+}
+
+func main() {
+	dataDir := flag.String("data", "data", "Directory with countries.csv, aliases.csv, codes.csv, meta.csv, demonyms.csv, historical.json, subdivisions.csv, postal_codes.csv and countries/*.json")
+	output := flag.String("out", "src/internal/data/embedded/countries.json", "Output file")
+	historicalOutput := flag.String("historical-out", "src/internal/data/embedded/historical.json", "Output file for historical countries")
+	subdivisionsOutput := flag.String("subdivisions-out", "src/internal/data/embedded/subdivisions.json", "Output file for subdivisions")
+	postalCodesOutput := flag.String("postal-codes-out", "src/internal/data/embedded/postal_codes.json", "Output file for postal code formats")
+	flag.Parse()
+
+	if err := run(*dataDir, *output); err != nil {
+		fmt.Fprintln(os.Stderr, "gendata:", err)
+		os.Exit(1)
+	}
+	if err := runHistorical(*dataDir, *historicalOutput, *output); err != nil {
+		fmt.Fprintln(os.Stderr, "gendata:", err)
+		os.Exit(1)
+	}
+	if err := runSubdivisions(*dataDir, *subdivisionsOutput, *output); err != nil {
+		fmt.Fprintln(os.Stderr, "gendata:", err)
+		os.Exit(1)
+	}
+	if err := runPostalCodes(*dataDir, *postalCodesOutput, *output); err != nil {
+		fmt.Fprintln(os.Stderr, "gendata:", err)
+		os.Exit(1)
+	}
+}
+
+func run(dataDir, output string) error {
+	base, err := cldrCountries()
+	if err != nil {
+		return err
+	}
+
+	codes, err := readCodes(filepath.Join(dataDir, "codes.csv"))
+	if err != nil {
+		return err
+	}
+
+	meta, err := readMeta(filepath.Join(dataDir, "meta.csv"))
+	if err != nil {
+		return err
+	}
+
+	demonyms, err := readDemonyms(filepath.Join(dataDir, "demonyms.csv"))
+	if err != nil {
+		return err
+	}
+
+	iso := make(map[string]bool, len(base))
+	for i := range base {
+		iso[base[i].ISO2] = true
+		base[i].Codes = codes[base[i].ISO2]
+		base[i].Meta = meta[base[i].ISO2]
+		base[i].Demonyms = demonyms[base[i].ISO2]
+	}
+	for code := range demonyms {
+		if !iso[code] {
+			return fmt.Errorf("demonyms.csv: unknown country %s", code)
+		}
+	}
+
+	loader := data.NewCompositeLoader([]data.Layer{
+		{Name: "cldr", Loader: staticLoader(base)},
+		{Name: "memory", Loader: data.NewMemoryLoader()},
+		{Name: "csv", Loader: data.NewCSVLoader(
+			filepath.Join(dataDir, "countries.csv"), filepath.Join(dataDir, "aliases.csv"), nil)},
+		{Name: "json", Loader: data.NewJSONLoader(filepath.Join(dataDir, "countries"))},
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	merged, err := loader.LoadCountries()
+	if err != nil {
+		return err
+	}
+
+	// Curated sources may know codes outside ISO 3166-1 (e.g. XK); the dataset does not
+	countries := make([]domain.Country, 0, len(merged))
+	for _, country := range merged {
+		if !iso[country.ISO2] {
+			continue
+		}
+		if country.Aliases == nil {
+			country.Aliases = []string{}
+		}
+		countries = append(countries, country)
+	}
+	sort.Slice(countries, func(i, j int) bool {
+		return countries[i].ISO2 < countries[j].ISO2
+	})
+
+	out, err := json.MarshalIndent(countries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(output, append(out, '\n'), 0o644); err != nil {
+		return err
+	}
+
+	fmt.Printf("wrote %d countries to %s\n", len(countries), output)
+	return nil
+}
+
+// cldrCountries returns every current ISO 3166-1 country with its codes and CLDR names
+func cldrCountries() ([]domain.Country, error) {
+	namers := make(map[string]display.Namer, len(languages))
+	for _, lang := range languages {
+		namers[lang] = display.Regions(language.MustParse(lang))
+	}
+
+	var countries []domain.Country
+	for a := 'A'; a <= 'Z'; a++ {
+		for b := 'A'; b <= 'Z'; b++ {
+			code := string([]rune{a, b})
+			region, err := language.ParseRegion(code)
+			// M49 is 0 for CLDR-only and reserved regions (AC, EU, IC, UN, ...)
+			if err != nil || !region.IsCountry() || region.String() != code || region.M49() == 0 || withdrawn[code] {
+				continue
+			}
+			// XK is a user-assigned code CLDR uses for Kosovo
+			if code == "XK" {
+				continue
+			}
+
+			names := make(map[string]string, len(languages))
+			for lang, namer := range namers {
+				if name := namer.Name(region); name != "" {
+					names[lang] = name
+				}
+			}
+			if name, ok := names["en"]; ok {
+				names["en"] = englishName(name)
+			}
+
+			countries = append(countries, domain.Country{
+				ISO2:    code,
+				ISO3:    region.ISO3(),
+				Numeric: fmt.Sprintf("%03d", region.M49()),
+				Names:   names,
+			})
+		}
+	}
+
+	if len(countries) != 249 {
+		return nil, fmt.Errorf("expected the 249 ISO 3166-1 countries, CLDR gave %d", len(countries))
+	}
+	return countries, nil
+}
+
+// readCodes reads the code systems table: an iso2 column followed by one column per
+// code system, named after the system
+func readCodes(path string) (map[string]map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if len(records) == 0 || records[0][0] != domain.CodeSystemISO2 {
+		return nil, fmt.Errorf("%s must start with an iso2 header column", path)
+	}
+
+	header := records[0]
+	for _, system := range header[1:] {
+		if !domain.IsCodeSystem(system) {
+			return nil, fmt.Errorf("%s: unknown code system %q", path, system)
+		}
+	}
+
+	codes := make(map[string]map[string]string, len(records)-1)
+	for row, record := range records[1:] {
+		countryCodes := make(map[string]string)
+		for i, value := range record[1:] {
+			if value == "" {
+				continue
+			}
+			code, ok := domain.NormalizeCode(header[i+1], value)
+			if !ok {
+				return nil, fmt.Errorf("%s row %d: %q is not a valid %s code", path, row+2, value, header[i+1])
+			}
+			countryCodes[header[i+1]] = code
+		}
+		codes[record[0]] = countryCodes
+	}
+	return codes, nil
+}
+
+// readMeta reads the metadata table: an iso2 column followed by one column per
+// metadata field, named after the field
+func readMeta(path string) (map[string]*domain.CountryMeta, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if len(records) == 0 || records[0][0] != domain.CodeSystemISO2 {
+		return nil, fmt.Errorf("%s must start with an iso2 header column", path)
+	}
+
+	header := records[0]
+	for _, field := range header[1:] {
+		if !domain.IsMetaField(field) {
+			return nil, fmt.Errorf("%s: unknown metadata field %q", path, field)
+		}
+	}
+
+	meta := make(map[string]*domain.CountryMeta, len(records)-1)
+	for row, record := range records[1:] {
+		countryMeta := &domain.CountryMeta{}
+		for i, value := range record[1:] {
+			if err := countryMeta.Set(header[i+1], value); err != nil {
+				return nil, fmt.Errorf("%s row %d: %w", path, row+2, err)
+			}
+		}
+		if !countryMeta.IsEmpty() {
+			meta[record[0]] = countryMeta
+		}
+	}
+	return meta, nil
+}
+
+// readDemonyms reads the demonyms table: an iso2 column followed by one column per
+// language, named after the language, with forms as read by domain.ParseDemonyms
+func readDemonyms(path string) (map[string]map[string][]domain.Demonym, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if len(records) == 0 || records[0][0] != domain.CodeSystemISO2 {
+		return nil, fmt.Errorf("%s must start with an iso2 header column", path)
+	}
+
+	header := records[0]
+	for i, lang := range header[1:] {
+		tag, err := language.Parse(lang)
+		if err != nil || tag.String() != lang {
+			return nil, fmt.Errorf("%s: %q is not a canonical language tag", path, lang)
+		}
+		header[i+1] = tag.String()
+	}
+
+	demonyms := make(map[string]map[string][]domain.Demonym, len(records)-1)
+	for row, record := range records[1:] {
+		countryDemonyms := make(map[string][]domain.Demonym)
+		for i, value := range record[1:] {
+			forms, err := domain.ParseDemonyms(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s row %d: %w", path, row+2, err)
+			}
+			if len(forms) > 0 {
+				countryDemonyms[header[i+1]] = forms
+			}
+		}
+		if len(countryDemonyms) > 0 {
+			demonyms[record[0]] = countryDemonyms
+		}
+	}
+	return demonyms, nil
+}
+
+// englishName spells out the abbreviations of CLDR's short English names,
+// e.g. "St. Pierre & Miquelon" becomes "Saint Pierre and Miquelon"
+func englishName(name string) string {
+	return strings.NewReplacer("St. ", "Saint ", " & ", " and ").Replace(name)
+}
+
+// staticLoader serves countries generated in memory
+type staticLoader []domain.Country
+
+func (l staticLoader) LoadCountries() ([]domain.Country, error) {
+	return l, nil
+}
+
+func (l staticLoader) LoadAliases() (map[string][]string, error) {
+	return map[string][]string{}, nil
+}
+
+// runHistorical validates data/historical.json against the generated countries and writes
+// it sorted by ISO 3166-3 code
+func runHistorical(dataDir, output, countriesFile string) error {
+	content, err := os.ReadFile(filepath.Join(dataDir, "historical.json"))
+	if err != nil {
+		return err
+	}
+	historical, err := data.ParseHistorical(content, "historical.json")
+	if err != nil {
+		return err
+	}
+
+	iso, err := generatedCodes(countriesFile)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(historical))
+	for _, country := range historical {
+		if seen[country.Code] {
+			return fmt.Errorf("duplicate historical country %s", country.Code)
+		}
+		seen[country.Code] = true
+		for _, successor := range country.Successors {
+			if !iso[successor] {
+				return fmt.Errorf("historical country %s has unknown successor %s", country.Code, successor)
+			}
+		}
+	}
+	sort.Slice(historical, func(i, j int) bool {
+		return historical[i].Code < historical[j].Code
+	})
+
+	out, err := json.MarshalIndent(historical, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(output, append(out, '\n'), 0o644); err != nil {
+		return err
+	}
+
+	fmt.Printf("wrote %d historical countries to %s\n", len(historical), output)
+	return nil
+}
+
+// runSubdivisions validates data/subdivisions.csv against the generated countries and
+// writes it sorted by code
+func runSubdivisions(dataDir, output, countriesFile string) error {
+	subdivisions, err := data.ReadSubdivisionTable(filepath.Join(dataDir, "subdivisions.csv"), ',')
+	if err != nil {
+		return err
+	}
+
+	iso, err := generatedCodes(countriesFile)
+	if err != nil {
+		return err
+	}
+	for _, subdivision := range subdivisions {
+		if !iso[subdivision.Country] {
+			return fmt.Errorf("subdivision %s belongs to unknown country %s", subdivision.Code, subdivision.Country)
+		}
+		if subdivision.Names["en"] == "" {
+			return fmt.Errorf("subdivision %s has no English name", subdivision.Code)
+		}
+	}
+	sort.Slice(subdivisions, func(i, j int) bool {
+		return subdivisions[i].Code < subdivisions[j].Code
+	})
+
+	out, err := json.MarshalIndent(subdivisions, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(output, append(out, '\n'), 0o644); err != nil {
+		return err
+	}
+
+	fmt.Printf("wrote %d subdivisions to %s\n", len(subdivisions), output)
+	return nil
+}
+
+// runPostalCodes validates data/postal_codes.csv against the generated countries and
+// writes it sorted by country, keeping the order of each country's formats
+func runPostalCodes(dataDir, output, countriesFile string) error {
+	formats, err := data.ReadPostalCodeTable(filepath.Join(dataDir, "postal_codes.csv"), ',')
+	if err != nil {
+		return err
+	}
+
+	iso, err := generatedCodes(countriesFile)
+	if err != nil {
+		return err
+	}
+	for _, format := range formats {
+		if !iso[format.Country] {
+			return fmt.Errorf("postal code format %q belongs to unknown country %s", format.Pattern, format.Country)
+		}
+		if format.Example == "" {
+			return fmt.Errorf("postal code format %q of %s has no example", format.Pattern, format.Country)
+		}
+	}
+	sort.SliceStable(formats, func(i, j int) bool {
+		return formats[i].Country < formats[j].Country
+	})
+
+	out, err := json.MarshalIndent(formats, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(output, append(out, '\n'), 0o644); err != nil {
+		return err
+	}
+
+	fmt.Printf("wrote %d postal code formats to %s\n", len(formats), output)
+	return nil
+}
+
+// generatedCodes returns the ISO2 codes of the generated countries file
+func generatedCodes(countriesFile string) (map[string]bool, error) {
+	content, err := os.ReadFile(countriesFile)
+	if err != nil {
+		return nil, err
+	}
+	var countries []domain.Country
+	if err := json.Unmarshal(content, &countries); err != nil {
+		return nil, err
+	}
+
+	iso := make(map[string]bool, len(countries))
+	for _, country := range countries {
+		iso[country.ISO2] = true
+	}
+	return iso, nil
+}
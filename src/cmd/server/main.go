@@ -7,11 +7,17 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"country-iso-matcher/src/internal/config"
+	"country-iso-matcher/src/internal/data"
 	"country-iso-matcher/src/internal/factory"
+	"country-iso-matcher/src/internal/server"
 )
 
+// shutdownTimeout bounds the graceful shutdown; calls and streams still open afterwards are cut off
+const shutdownTimeout = 30 * time.Second
+
 func main() {
 	// Parse command-line flags
 	configPath := flag.String("config", "", "Path to configuration file (YAML)")
@@ -46,31 +52,66 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Build and start server
-	server, err := appFactory.CreateHTTPServer()
+	// Build and start servers
+	httpServer, err := appFactory.CreateHTTPServer()
 	if err != nil {
 		logger.Error("Failed to create HTTP server", "error", err)
 		os.Exit(1)
 	}
 
+	var grpcServer server.Server
+	if cfg.GRPC.Enabled {
+		grpcServer, err = appFactory.CreateGRPCServer()
+		if err != nil {
+			logger.Error("Failed to create gRPC server", "error", err)
+			os.Exit(1)
+		}
+
+		go func() {
+			if err := grpcServer.Start(); err != nil {
+				logger.Error("gRPC server failed to start", "error", err)
+				os.Exit(1)
+			}
+		}()
+	}
+
 	// Graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Live reload of the country data on SIGHUP and on data file changes
+	reloader, err := appFactory.Reloader()
+	if err != nil {
+		logger.Error("Failed to create data reloader", "error", err)
+		os.Exit(1)
+	}
+	go reloader.WatchSignals(ctx)
+	if cfg.Data.WatchInterval > 0 {
+		go reloader.WatchFiles(ctx, data.WatchPatterns(&cfg.Data), time.Duration(cfg.Data.WatchInterval)*time.Second)
+	}
+
 	go func() {
 		sigCh := make(chan os.Signal, 1)
 		signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 		<-sigCh
 
 		logger.Info("shutting down server...")
-		if err := server.Shutdown(ctx); err != nil {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer shutdownCancel()
+
+		if grpcServer != nil {
+			if err := grpcServer.Shutdown(shutdownCtx); err != nil {
+				logger.Error("gRPC server shutdown failed", "error", err)
+			}
+		}
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
 			logger.Error("server shutdown failed", "error", err)
 		}
 		cancel()
 	}()
 
 	logger.Info("server listening", "address", cfg.Server.Host+":"+cfg.Server.Port)
-	if err := server.Start(); err != nil {
+	if err := httpServer.Start(); err != nil {
 		logger.Error("server failed to start", "error", err)
 		os.Exit(1)
 	}
@@ -84,6 +84,11 @@ func loadFromEnv(cfg *Config) {
 			cfg.Server.WriteTimeout = timeout
 		}
 	}
+	if v := os.Getenv("SERVER_STREAM_TIMEOUT"); v != "" {
+		if timeout, err := strconv.Atoi(v); err == nil {
+			cfg.Server.StreamTimeout = timeout
+		}
+	}
 
 	// Database configuration
 	if v := os.Getenv("DB_ENABLED"); v != "" {
@@ -126,6 +131,12 @@ func loadFromEnv(cfg *Config) {
 	if v := os.Getenv("DB_NAME_COLUMN"); v != "" {
 		cfg.Database.Schema.NameColumn = v
 	}
+	if v := os.Getenv("DB_ISO3_COLUMN"); v != "" {
+		cfg.Database.Schema.ISO3Column = v
+	}
+	if v := os.Getenv("DB_NUMERIC_COLUMN"); v != "" {
+		cfg.Database.Schema.NumericColumn = v
+	}
 	if v := os.Getenv("DB_ALIAS_CODE_COLUMN"); v != "" {
 		cfg.Database.Schema.AliasCodeColumn = v
 	}
@@ -137,12 +148,83 @@ func loadFromEnv(cfg *Config) {
 	if v := os.Getenv("DATA_SOURCE"); v != "" {
 		cfg.Data.Source = v
 	}
+	if v := os.Getenv("DATA_COUNTRIES_DIR"); v != "" {
+		cfg.Data.CountriesDir = v
+	}
 	if v := os.Getenv("DATA_COUNTRIES_FILE"); v != "" {
 		cfg.Data.CountriesFile = v
 	}
 	if v := os.Getenv("DATA_ALIASES_FILE"); v != "" {
 		cfg.Data.AliasesFile = v
 	}
+	if v := os.Getenv("DATA_HISTORICAL_FILE"); v != "" {
+		cfg.Data.HistoricalFile = v
+	}
+	if v := os.Getenv("DATA_SUBDIVISIONS_FILE"); v != "" {
+		cfg.Data.SubdivisionsFile = v
+	}
+	if v := os.Getenv("DATA_POSTAL_CODES_FILE"); v != "" {
+		cfg.Data.PostalCodesFile = v
+	}
+	if v := os.Getenv("DATA_WATCH_INTERVAL"); v != "" {
+		if interval, err := strconv.Atoi(v); err == nil {
+			cfg.Data.WatchInterval = interval
+		}
+	}
+
+	// Matching configuration
+	if v := os.Getenv("MATCHING_FUZZY_ENABLED"); v != "" {
+		cfg.Matching.FuzzyEnabled = v == "true" || v == "1"
+	}
+	if v := os.Getenv("MATCHING_FUZZY_MAX_DISTANCE"); v != "" {
+		if distance, err := strconv.Atoi(v); err == nil {
+			cfg.Matching.FuzzyMaxDistance = distance
+		}
+	}
+	if v := os.Getenv("MATCHING_SUBDIVISION_FALLBACK"); v != "" {
+		cfg.Matching.SubdivisionFallback = v == "true" || v == "1"
+	}
+	if v := os.Getenv("MATCHING_FUZZY_MIN_LENGTH"); v != "" {
+		if length, err := strconv.Atoi(v); err == nil {
+			cfg.Matching.FuzzyMinLength = length
+		}
+	}
+
+	// API configuration
+	if v := os.Getenv("API_MAX_BATCH_SIZE"); v != "" {
+		if size, err := strconv.Atoi(v); err == nil {
+			cfg.API.MaxBatchSize = size
+		}
+	}
+	if v := os.Getenv("API_MAX_UPLOAD_SIZE"); v != "" {
+		if size, err := strconv.ParseInt(v, 10, 64); err == nil {
+			cfg.API.MaxUploadSize = size
+		}
+	}
+	if v := os.Getenv("API_MAX_TEXT_SIZE"); v != "" {
+		if size, err := strconv.ParseInt(v, 10, 64); err == nil {
+			cfg.API.MaxTextSize = size
+		}
+	}
+
+	// gRPC configuration
+	if v := os.Getenv("GRPC_ENABLED"); v != "" {
+		cfg.GRPC.Enabled = v == "true" || v == "1"
+	}
+	if v := os.Getenv("GRPC_PORT"); v != "" {
+		cfg.GRPC.Port = v
+	}
+	if v := os.Getenv("GRPC_REFLECTION"); v != "" {
+		cfg.GRPC.Reflection = v == "true" || v == "1"
+	}
+
+	// Admin configuration
+	if v := os.Getenv("ADMIN_ENABLED"); v != "" {
+		cfg.Admin.Enabled = v == "true" || v == "1"
+	}
+	if v := os.Getenv("ADMIN_TOKEN"); v != "" {
+		cfg.Admin.Token = v
+	}
 
 	// Logging configuration
 	if v := os.Getenv("LOG_LEVEL"); v != "" {
@@ -5,6 +5,10 @@ type Config struct {
 	Server   ServerConfig   `yaml:"server" json:"server"`
 	Database DatabaseConfig `yaml:"database" json:"database"`
 	Data     DataConfig     `yaml:"data" json:"data"`
+	Matching MatchingConfig `yaml:"matching" json:"matching"`
+	API      APIConfig      `yaml:"api" json:"api"`
+	GRPC     GRPCConfig     `yaml:"grpc" json:"grpc"`
+	Admin    AdminConfig    `yaml:"admin" json:"admin"`
 	Logging  LoggingConfig  `yaml:"logging" json:"logging"`
 	GUI      GUIConfig      `yaml:"gui" json:"gui"`
 }
@@ -16,6 +20,10 @@ type ServerConfig struct {
 	Environment  string `yaml:"environment" json:"environment"`
 	ReadTimeout  int    `yaml:"read_timeout" json:"read_timeout"`
 	WriteTimeout int    `yaml:"write_timeout" json:"write_timeout"`
+
+	// StreamTimeout replaces ReadTimeout and WriteTimeout on streaming routes, in seconds.
+	// Streams run as long as the client keeps sending, so 0 disables the deadline entirely.
+	StreamTimeout int `yaml:"stream_timeout" json:"stream_timeout"`
 }
 
 // DatabaseConfig contains database connection configuration
@@ -35,20 +43,106 @@ type DatabaseConfig struct {
 
 // SchemaConfig defines database table and column names
 type SchemaConfig struct {
-	CountriesTable   string `yaml:"countries_table" json:"countries_table"`
-	AliasesTable     string `yaml:"aliases_table" json:"aliases_table"`
-	CodeColumn       string `yaml:"code_column" json:"code_column"`
-	NameColumn       string `yaml:"name_column" json:"name_column"`
-	AliasCodeColumn  string `yaml:"alias_code_column" json:"alias_code_column"`
-	AliasNameColumn  string `yaml:"alias_name_column" json:"alias_name_column"`
+	CountriesTable string `yaml:"countries_table" json:"countries_table"`
+	AliasesTable   string `yaml:"aliases_table" json:"aliases_table"`
+	CodeColumn     string `yaml:"code_column" json:"code_column"`
+	NameColumn     string `yaml:"name_column" json:"name_column"`
+	ISO3Column     string `yaml:"iso3_column" json:"iso3_column"`       // optional; without it countries have no ISO3 code
+	NumericColumn  string `yaml:"numeric_column" json:"numeric_column"` // optional ISO 3166-1 numeric code
+	// CodeColumns maps code systems (ioc, fifa, itu, fips, tld, vehicle, m49) to optional columns
+	CodeColumns map[string]string `yaml:"code_columns,omitempty" json:"code_columns,omitempty"`
+	// MetaColumns maps metadata fields (continent, region, subregion, capital, currencies, languages) to optional columns
+	MetaColumns     map[string]string `yaml:"meta_columns,omitempty" json:"meta_columns,omitempty"`
+	AliasCodeColumn string            `yaml:"alias_code_column" json:"alias_code_column"`
+	AliasNameColumn string            `yaml:"alias_name_column" json:"alias_name_column"`
 }
 
 // DataConfig specifies the data source configuration
 type DataConfig struct {
-	Source        string `yaml:"source" json:"source"` // json, memory, csv, tsv, database
+	Source        string `yaml:"source" json:"source"`               // embedded, json, memory, csv, tsv, database, composite
 	CountriesDir  string `yaml:"countries_dir" json:"countries_dir"` // for JSON source
 	CountriesFile string `yaml:"countries_file" json:"countries_file"`
-	AliasesFile   string `yaml:"aliases_file" json:"aliases_file"`
+	AliasesFile   string `yaml:"aliases_file" json:"aliases_file"` // optional for csv/tsv
+
+	// Columns maps header names of a CSV/TSV countries file to column roles
+	// (iso2, iso3, numeric, aliases, name_<lang>) when they differ from the standard names
+	Columns map[string]string `yaml:"columns,omitempty" json:"columns,omitempty"`
+
+	// Layers are the sources of the composite source, base first; later layers override earlier ones
+	Layers []DataLayerConfig `yaml:"layers,omitempty" json:"layers,omitempty"`
+
+	// HistoricalFile is an optional JSON file of countries withdrawn from ISO 3166-1
+	// (see data/historical.json); the embedded ISO 3166-3 list is used when empty
+	HistoricalFile string `yaml:"historical_file,omitempty" json:"historical_file,omitempty"`
+
+	// SubdivisionsFile is an optional CSV or TSV file of ISO 3166-2 subdivisions
+	// (see data/subdivisions.csv); the embedded subdivisions are used when empty
+	SubdivisionsFile string `yaml:"subdivisions_file,omitempty" json:"subdivisions_file,omitempty"`
+
+	// PostalCodesFile is an optional CSV or TSV file of postal code formats per country
+	// (see data/postal_codes.csv); the embedded formats are used when empty
+	PostalCodesFile string `yaml:"postal_codes_file,omitempty" json:"postal_codes_file,omitempty"`
+
+	// WatchInterval is how often the data files are polled for changes, in seconds.
+	// 0 disables the watcher; SIGHUP and the admin endpoint still reload.
+	WatchInterval int `yaml:"watch_interval" json:"watch_interval"`
+}
+
+// DataLayerConfig is one source of a composite data source
+type DataLayerConfig struct {
+	Name          string            `yaml:"name" json:"name"`     // used in logs; defaults to the source
+	Source        string            `yaml:"source" json:"source"` // embedded, json, memory, csv, tsv, database
+	CountriesDir  string            `yaml:"countries_dir,omitempty" json:"countries_dir,omitempty"`
+	CountriesFile string            `yaml:"countries_file,omitempty" json:"countries_file,omitempty"`
+	AliasesFile   string            `yaml:"aliases_file,omitempty" json:"aliases_file,omitempty"`
+	Columns       map[string]string `yaml:"columns,omitempty" json:"columns,omitempty"`
+
+	// Delete removes countries (by ISO2 code) defined by earlier layers
+	Delete []string `yaml:"delete,omitempty" json:"delete,omitempty"`
+	// DeleteAliases removes aliases, by ISO2 code, defined by earlier layers
+	DeleteAliases map[string][]string `yaml:"delete_aliases,omitempty" json:"delete_aliases,omitempty"`
+}
+
+// DataConfig returns the layer's source settings as a standalone data configuration
+func (l *DataLayerConfig) DataConfig() DataConfig {
+	return DataConfig{
+		Source:        l.Source,
+		CountriesDir:  l.CountriesDir,
+		CountriesFile: l.CountriesFile,
+		AliasesFile:   l.AliasesFile,
+		Columns:       l.Columns,
+	}
+}
+
+// LayerName returns the name of the layer used in logs
+func (l *DataLayerConfig) LayerName() string {
+	if l.Name != "" {
+		return l.Name
+	}
+	return l.Source
+}
+
+// MatchingConfig controls how country names are matched
+type MatchingConfig struct {
+	FuzzyEnabled     bool `yaml:"fuzzy_enabled" json:"fuzzy_enabled"`
+	FuzzyMaxDistance int  `yaml:"fuzzy_max_distance" json:"fuzzy_max_distance"` // maximum edit distance for typo tolerance
+	FuzzyMinLength   int  `yaml:"fuzzy_min_length" json:"fuzzy_min_length"`     // queries shorter than this are never fuzzy matched
+
+	// HistoricalSuccessors maps ISO 3166-3 codes of withdrawn countries to the ISO2 code of
+	// the current country they resolve to (e.g. SUHH: RU). Historical countries without an
+	// entry resolve to themselves, listing all their successors.
+	HistoricalSuccessors map[string]string `yaml:"historical_successors,omitempty" json:"historical_successors,omitempty"`
+
+	// SubdivisionFallback resolves queries naming a subdivision (e.g. "Bavaria") to its
+	// country when they match no country exactly
+	SubdivisionFallback bool `yaml:"subdivision_fallback" json:"subdivision_fallback"`
+}
+
+// APIConfig contains limits for the bulk API endpoints
+type APIConfig struct {
+	MaxBatchSize  int   `yaml:"max_batch_size" json:"max_batch_size"`   // maximum queries per batch request
+	MaxUploadSize int64 `yaml:"max_upload_size" json:"max_upload_size"` // maximum uploaded file size in bytes
+	MaxTextSize   int64 `yaml:"max_text_size" json:"max_text_size"`     // maximum text size in bytes for extraction
 }
 
 // LoggingConfig contains logging configuration
@@ -57,6 +151,19 @@ type LoggingConfig struct {
 	Format string `yaml:"format" json:"format"` // json, text
 }
 
+// GRPCConfig contains gRPC server configuration. The server listens on Server.Host.
+type GRPCConfig struct {
+	Enabled    bool   `yaml:"enabled" json:"enabled"`
+	Port       string `yaml:"port" json:"port"`
+	Reflection bool   `yaml:"reflection" json:"reflection"` // expose the server reflection service
+}
+
+// AdminConfig contains configuration of the admin endpoints, such as the data reload
+type AdminConfig struct {
+	Enabled bool   `yaml:"enabled" json:"enabled"`
+	Token   string `yaml:"token" json:"-"` // bearer token required by every admin endpoint; never sent to the GUI
+}
+
 // GUIConfig contains GUI-related configuration
 type GUIConfig struct {
 	Enabled bool   `yaml:"enabled" json:"enabled"`
@@ -67,11 +174,12 @@ type GUIConfig struct {
 func DefaultConfig() *Config {
 	return &Config{
 		Server: ServerConfig{
-			Port:         "3030",
-			Host:         "0.0.0.0",
-			Environment:  "development",
-			ReadTimeout:  10,
-			WriteTimeout: 10,
+			Port:          "3030",
+			Host:          "0.0.0.0",
+			Environment:   "development",
+			ReadTimeout:   10,
+			WriteTimeout:  10,
+			StreamTimeout: 3600,
 		},
 		Database: DatabaseConfig{
 			Enabled:  false,
@@ -92,15 +200,34 @@ func DefaultConfig() *Config {
 			},
 		},
 		Data: DataConfig{
-			Source:        "json",
+			Source:        "embedded",
 			CountriesDir:  "data/countries",
 			CountriesFile: "data/countries.csv",
 			AliasesFile:   "data/aliases.csv",
+			WatchInterval: 5,
+		},
+		Matching: MatchingConfig{
+			FuzzyEnabled:     true,
+			FuzzyMaxDistance: 2,
+			FuzzyMinLength:   4,
+		},
+		API: APIConfig{
+			MaxBatchSize:  1000,
+			MaxUploadSize: 100 << 20, // 100 MB
+			MaxTextSize:   1 << 20,   // 1 MB
 		},
 		Logging: LoggingConfig{
 			Level:  "info",
 			Format: "json",
 		},
+		GRPC: GRPCConfig{
+			Enabled:    false, // Opt-in: the gRPC port is not authenticated
+			Port:       "9090",
+			Reflection: false,
+		},
+		Admin: AdminConfig{
+			Enabled: false,
+		},
 		GUI: GUIConfig{
 			Enabled: true,
 			Path:    "/admin",
@@ -25,6 +25,26 @@ func Validate(cfg *Config) error {
 		return fmt.Errorf("data source config: %w", err)
 	}
 
+	// Validate matching configuration
+	if err := validateMatching(&cfg.Matching); err != nil {
+		return fmt.Errorf("matching config: %w", err)
+	}
+
+	// Validate API configuration
+	if err := validateAPI(&cfg.API); err != nil {
+		return fmt.Errorf("api config: %w", err)
+	}
+
+	// Validate gRPC configuration
+	if err := validateGRPC(&cfg.GRPC, &cfg.Server); err != nil {
+		return fmt.Errorf("grpc config: %w", err)
+	}
+
+	// Validate admin configuration
+	if err := validateAdmin(&cfg.Admin); err != nil {
+		return fmt.Errorf("admin config: %w", err)
+	}
+
 	// Validate logging configuration
 	if err := validateLogging(&cfg.Logging); err != nil {
 		return fmt.Errorf("logging config: %w", err)
@@ -46,6 +66,10 @@ func validateServer(cfg *ServerConfig) error {
 		return fmt.Errorf("write_timeout must be positive")
 	}
 
+	if cfg.StreamTimeout < 0 {
+		return fmt.Errorf("stream_timeout cannot be negative")
+	}
+
 	validEnvs := map[string]bool{
 		"development": true,
 		"staging":     true,
@@ -71,6 +95,10 @@ func validateDatabase(cfg *DatabaseConfig) error {
 		return fmt.Errorf("invalid database type: %s (must be postgres, mysql, or sqlite)", cfg.Type)
 	}
 
+	if cfg.Type == "sqlite" && cfg.Database == "" {
+		return fmt.Errorf("database must be the database file path for sqlite")
+	}
+
 	if cfg.Type != "sqlite" {
 		if cfg.Host == "" {
 			return fmt.Errorf("host cannot be empty for %s", cfg.Type)
@@ -111,15 +139,33 @@ func validateDatabase(cfg *DatabaseConfig) error {
 
 func validateDataSource(cfg *DataConfig, dbEnabled bool) error {
 	validSources := map[string]bool{
-		"json":     true,
-		"memory":   true,
-		"csv":      true,
-		"tsv":      true,
-		"database": true,
+		"embedded":  true,
+		"json":      true,
+		"memory":    true,
+		"csv":       true,
+		"tsv":       true,
+		"database":  true,
+		"composite": true,
 	}
 
 	if !validSources[cfg.Source] {
-		return fmt.Errorf("invalid data source: %s (must be json, memory, csv, tsv, or database)", cfg.Source)
+		return fmt.Errorf("invalid data source: %s (must be embedded, json, memory, csv, tsv, database, or composite)", cfg.Source)
+	}
+
+	if cfg.Source == "composite" {
+		if len(cfg.Layers) == 0 {
+			return fmt.Errorf("composite source needs at least one layer")
+		}
+
+		for i := range cfg.Layers {
+			layer := cfg.Layers[i].DataConfig()
+			if layer.Source == "composite" {
+				return fmt.Errorf("layer %d: composite sources cannot be nested", i+1)
+			}
+			if err := validateDataSource(&layer, dbEnabled); err != nil {
+				return fmt.Errorf("layer %d (%s): %w", i+1, cfg.Layers[i].LayerName(), err)
+			}
+		}
 	}
 
 	// If source is database, database must be enabled
@@ -147,24 +193,91 @@ func validateDataSource(cfg *DataConfig, dbEnabled bool) error {
 			return fmt.Errorf("countries_file cannot be empty for %s source", cfg.Source)
 		}
 
-		if cfg.AliasesFile == "" {
-			return fmt.Errorf("aliases_file cannot be empty for %s source", cfg.Source)
-		}
-
 		// Check if files exist (warning, not error)
 		if _, err := os.Stat(cfg.CountriesFile); os.IsNotExist(err) {
 			// This is just a warning - file might be created later
 			// But we'll validate in the data loader
 		}
 
-		if _, err := os.Stat(cfg.AliasesFile); os.IsNotExist(err) {
-			// This is just a warning
+		if cfg.AliasesFile != "" {
+			if _, err := os.Stat(cfg.AliasesFile); os.IsNotExist(err) {
+				// This is just a warning
+			}
+		}
+	}
+
+	if cfg.WatchInterval < 0 {
+		return fmt.Errorf("watch_interval cannot be negative")
+	}
+
+	return nil
+}
+
+func validateMatching(cfg *MatchingConfig) error {
+	if cfg.FuzzyMaxDistance < 0 {
+		return fmt.Errorf("fuzzy_max_distance cannot be negative")
+	}
+
+	if cfg.FuzzyEnabled && cfg.FuzzyMaxDistance == 0 {
+		return fmt.Errorf("fuzzy_max_distance must be positive when fuzzy matching is enabled")
+	}
+
+	if cfg.FuzzyMinLength < 0 {
+		return fmt.Errorf("fuzzy_min_length cannot be negative")
+	}
+
+	for historical, successor := range cfg.HistoricalSuccessors {
+		if !isUpperCode(historical, 4) {
+			return fmt.Errorf("historical_successors: %q is not an ISO 3166-3 code", historical)
+		}
+		if !isUpperCode(successor, 2) {
+			return fmt.Errorf("historical_successors: successor %q of %s is not an ISO2 code", successor, historical)
 		}
 	}
 
 	return nil
 }
 
+func validateAPI(cfg *APIConfig) error {
+	if cfg.MaxBatchSize <= 0 {
+		return fmt.Errorf("max_batch_size must be positive")
+	}
+
+	if cfg.MaxUploadSize <= 0 {
+		return fmt.Errorf("max_upload_size must be positive")
+	}
+
+	if cfg.MaxTextSize <= 0 {
+		return fmt.Errorf("max_text_size must be positive")
+	}
+
+	return nil
+}
+
+func validateGRPC(cfg *GRPCConfig, server *ServerConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.Port == "" {
+		return fmt.Errorf("port cannot be empty")
+	}
+
+	if cfg.Port == server.Port {
+		return fmt.Errorf("port %s is already used by the HTTP server", cfg.Port)
+	}
+
+	return nil
+}
+
+func validateAdmin(cfg *AdminConfig) error {
+	if cfg.Enabled && cfg.Token == "" {
+		return fmt.Errorf("token is required when the admin endpoints are enabled")
+	}
+
+	return nil
+}
+
 func validateLogging(cfg *LoggingConfig) error {
 	validLevels := map[string]bool{
 		"debug": true,
@@ -192,3 +305,16 @@ func validateLogging(cfg *LoggingConfig) error {
 
 	return nil
 }
+
+// isUpperCode reports whether code consists of exactly n upper-case ASCII letters
+func isUpperCode(code string, n int) bool {
+	if len(code) != n {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,297 @@
+package data
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"country-iso-matcher/src/internal/domain"
+)
+
+// Layer is one source of a CompositeLoader
+type Layer struct {
+	Name   string
+	Loader Loader
+
+	// Delete lists ISO2 codes of countries from earlier layers to remove; it is applied
+	// before the layer's own countries, so a layer can replace a country entirely
+	Delete []string
+	// DeleteAliases lists aliases from earlier layers to remove, by ISO2 code
+	DeleteAliases map[string][]string
+}
+
+// CompositeLoader merges several sources, base layer first. Countries are merged by ISO2:
+// a later layer adds countries, overrides ISO3, numeric codes, other codes per code system
+// and names per language, adds aliases and can delete countries or aliases of earlier layers.
+// Load merges countries and aliases in one pass over the layers.
+type CompositeLoader struct {
+	layers []Layer
+	logger *slog.Logger
+}
+
+// NewCompositeLoader creates a loader merging layers in order
+func NewCompositeLoader(layers []Layer, logger *slog.Logger) *CompositeLoader {
+	return &CompositeLoader{
+		layers: layers,
+		logger: logger,
+	}
+}
+
+// LoadCountries merges the countries of all layers, logging each layer's contribution
+// and every value a layer overrides
+func (l *CompositeLoader) LoadCountries() ([]domain.Country, error) {
+	countries, _, err := l.merge(true)
+	return countries, err
+}
+
+// LoadAliases merges the aliases of all layers
+func (l *CompositeLoader) LoadAliases() (map[string][]string, error) {
+	_, aliases, err := l.merge(false)
+	return aliases, err
+}
+
+// Load merges the countries and aliases of all layers, loading each layer once, so both
+// come from the same snapshot of the layers. Contributions and conflicts are logged.
+func (l *CompositeLoader) Load() ([]domain.Country, map[string][]string, error) {
+	return l.merge(true)
+}
+
+// layerStats counts what a layer contributed
+type layerStats struct {
+	added, updated, deleted, aliases, conflicts int
+}
+
+// merge loads every layer and folds it into the result of the previous ones.
+// With report set, contributions and conflicts are logged.
+func (l *CompositeLoader) merge(report bool) ([]domain.Country, map[string][]string, error) {
+	var order []string
+	ordered := make(map[string]bool)
+	merged := make(map[string]*domain.Country)
+	aliases := make(map[string][]string)
+	aliasOwner := make(map[string]string) // lowercased alias -> ISO2 of the country that has it
+
+	for _, layer := range l.layers {
+		countries, err := layer.Loader.LoadCountries()
+		if err != nil {
+			return nil, nil, fmt.Errorf("layer %s: %w", layer.Name, err)
+		}
+		layerAliases, err := layer.Loader.LoadAliases()
+		if err != nil {
+			return nil, nil, fmt.Errorf("layer %s: %w", layer.Name, err)
+		}
+
+		var stats layerStats
+		conflict := func(code, field, previous, value string) {
+			stats.conflicts++
+			if report {
+				l.logger.Warn("data layer overrides value",
+					"layer", layer.Name, "country", code, "field", field, "previous", previous, "value", value)
+			}
+		}
+
+		for _, code := range layer.Delete {
+			code = strings.ToUpper(code)
+			if _, exists := merged[code]; !exists {
+				continue
+			}
+			delete(merged, code)
+			delete(aliases, code)
+			stats.deleted++
+		}
+
+		for i := range countries {
+			country := countries[i]
+
+			existing, exists := merged[country.ISO2]
+			if !exists {
+				names := make(map[string]string, len(country.Names))
+				for lang, name := range country.Names {
+					names[lang] = name
+				}
+				country.Names = names
+				if country.Codes != nil {
+					codes := make(map[string]string, len(country.Codes))
+					for system, code := range country.Codes {
+						codes[system] = code
+					}
+					country.Codes = codes
+				}
+				if country.Meta != nil {
+					meta := *country.Meta // Set replaces list fields, so sharing them is safe
+					country.Meta = &meta
+				}
+				if country.Demonyms != nil {
+					demonyms := make(map[string][]domain.Demonym, len(country.Demonyms))
+					for lang, forms := range country.Demonyms {
+						demonyms[lang] = forms
+					}
+					country.Demonyms = demonyms
+				}
+				merged[country.ISO2] = &country
+				if !ordered[country.ISO2] { // A deleted country may come back in a later layer
+					order = append(order, country.ISO2)
+					ordered[country.ISO2] = true
+				}
+				stats.added++
+				continue
+			}
+
+			changed := false
+			if country.ISO3 != "" && country.ISO3 != existing.ISO3 {
+				if existing.ISO3 != "" {
+					conflict(country.ISO2, "iso3", existing.ISO3, country.ISO3)
+				}
+				existing.ISO3 = country.ISO3
+				changed = true
+			}
+			if country.Numeric != "" && country.Numeric != existing.Numeric {
+				if existing.Numeric != "" {
+					conflict(country.ISO2, "numeric", existing.Numeric, country.Numeric)
+				}
+				existing.Numeric = country.Numeric
+				changed = true
+			}
+			for system, code := range country.Codes {
+				previous, exists := existing.Codes[system]
+				if previous == code {
+					continue
+				}
+				if exists {
+					conflict(country.ISO2, "code_"+system, previous, code)
+				}
+				if existing.Codes == nil {
+					existing.Codes = make(map[string]string)
+				}
+				existing.Codes[system] = code
+				changed = true
+			}
+			for _, field := range domain.MetaFields {
+				value := country.Meta.Get(field)
+				previous := existing.Meta.Get(field)
+				if value == "" || value == previous {
+					continue
+				}
+				if previous != "" {
+					conflict(country.ISO2, RoleMetaPrefix+field, previous, value)
+				}
+				if existing.Meta == nil {
+					existing.Meta = &domain.CountryMeta{}
+				}
+				if err := existing.Meta.Set(field, value); err != nil {
+					return nil, nil, fmt.Errorf("layer %s: country %s: %w", layer.Name, country.ISO2, err)
+				}
+				changed = true
+			}
+			// A layer's demonyms replace the forms of their language as a whole
+			for lang, forms := range country.Demonyms {
+				previous, exists := existing.Demonyms[lang]
+				value := domain.FormatDemonyms(forms)
+				if exists && domain.FormatDemonyms(previous) == value {
+					continue
+				}
+				if exists {
+					conflict(country.ISO2, RoleDemonymPrefix+lang, domain.FormatDemonyms(previous), value)
+				}
+				if existing.Demonyms == nil {
+					existing.Demonyms = make(map[string][]domain.Demonym)
+				}
+				existing.Demonyms[lang] = forms
+				changed = true
+			}
+			for lang, name := range country.Names {
+				previous, exists := existing.Names[lang]
+				if previous == name {
+					continue
+				}
+				if exists {
+					conflict(country.ISO2, "name_"+lang, previous, name)
+				}
+				existing.Names[lang] = name
+				changed = true
+			}
+			if changed {
+				stats.updated++
+			}
+		}
+
+		for code, removed := range layer.DeleteAliases {
+			code = strings.ToUpper(code)
+			aliases[code] = removeAliases(aliases[code], removed)
+		}
+
+		for code, names := range layerAliases {
+			for _, alias := range names {
+				key := strings.ToLower(alias)
+				if owner, exists := aliasOwner[key]; exists && owner != code {
+					if _, stillOwned := merged[owner]; stillOwned && containsAlias(aliases[owner], alias) {
+						stats.conflicts++
+						if report {
+							l.logger.Warn("data layer assigns alias to another country",
+								"layer", layer.Name, "alias", alias, "country", code, "previous_country", owner)
+						}
+					}
+				}
+				if containsAlias(aliases[code], alias) {
+					continue
+				}
+				aliases[code] = append(aliases[code], alias)
+				aliasOwner[key] = code
+				stats.aliases++
+			}
+		}
+
+		if report {
+			l.logger.Info("data layer loaded",
+				"layer", layer.Name,
+				"countries", len(countries),
+				"added", stats.added,
+				"updated", stats.updated,
+				"deleted", stats.deleted,
+				"aliases_added", stats.aliases,
+				"conflicts", stats.conflicts,
+			)
+		}
+	}
+
+	// Aliases of deleted countries, or of countries no layer defines, are dropped
+	for code := range aliases {
+		if _, exists := merged[code]; !exists || len(aliases[code]) == 0 {
+			delete(aliases, code)
+		}
+	}
+
+	countries := make([]domain.Country, 0, len(merged))
+	for _, code := range order {
+		if country, exists := merged[code]; exists {
+			country.Aliases = aliases[code]
+			countries = append(countries, *country)
+		}
+	}
+
+	if len(countries) == 0 {
+		return nil, nil, fmt.Errorf("no countries left after merging %d layers", len(l.layers))
+	}
+
+	return countries, aliases, nil
+}
+
+// containsAlias reports whether aliases has alias, ignoring case
+func containsAlias(aliases []string, alias string) bool {
+	for _, existing := range aliases {
+		if strings.EqualFold(existing, alias) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeAliases returns aliases without the removed ones, ignoring case
+func removeAliases(aliases, removed []string) []string {
+	kept := aliases[:0:0]
+	for _, alias := range aliases {
+		if !containsAlias(removed, alias) {
+			kept = append(kept, alias)
+		}
+	}
+	return kept
+}
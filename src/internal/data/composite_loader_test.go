@@ -0,0 +1,220 @@
+package data_test
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"country-iso-matcher/src/internal/data"
+	"country-iso-matcher/src/internal/domain"
+)
+
+func TestCompositeLoader_MergesLayers(t *testing.T) {
+	base := writeFile(t, "base.csv", strings.Join([]string{
+		"iso2,iso3,numeric,name_en,aliases",
+		"DE,DEU,276,Germany,deutschland",
+		"RO,ROU,642,Romania,",
+		"CS,SCG,891,Serbia and Montenegro,",
+	}, "\n"))
+	company := writeFile(t, "company.csv", strings.Join([]string{
+		"iso2,name_en,name_de,aliases",
+		"DE,Federal Republic of Germany,Deutschland,brd|germany inc",
+		"XK,Kosovo,Kosovo,",
+	}, "\n"))
+	team := writeFile(t, "team.csv", strings.Join([]string{
+		"iso2,name_en,aliases",
+		"RO,Romania,germany inc",
+	}, "\n"))
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+
+	loader := data.NewCompositeLoader([]data.Layer{
+		{Name: "base", Loader: data.NewCSVLoader(base, "", nil)},
+		{Name: "company", Loader: data.NewCSVLoader(company, "", nil)},
+		{
+			Name:          "team",
+			Loader:        data.NewCSVLoader(team, "", nil),
+			Delete:        []string{"cs"},
+			DeleteAliases: map[string][]string{"DE": {"Germany Inc"}},
+		},
+	}, logger)
+
+	countries, err := loader.LoadCountries()
+	if err != nil {
+		t.Fatalf("failed to load countries: %v", err)
+	}
+
+	var codes []string
+	for _, country := range countries {
+		codes = append(codes, country.ISO2)
+	}
+	if got := strings.Join(codes, ","); got != "DE,RO,XK" {
+		t.Fatalf("unexpected countries: %s", got)
+	}
+
+	germany := countries[0]
+	if germany.ISO3 != "DEU" || germany.Numeric != "276" {
+		t.Errorf("expected base codes to survive a layer without them: %+v", germany)
+	}
+	if germany.Names["en"] != "Federal Republic of Germany" || germany.Names["de"] != "Deutschland" {
+		t.Errorf("unexpected names: %v", germany.Names)
+	}
+
+	aliases, err := loader.LoadAliases()
+	if err != nil {
+		t.Fatalf("failed to load aliases: %v", err)
+	}
+	if got := strings.Join(aliases["DE"], ","); got != "deutschland,brd" {
+		t.Errorf("unexpected DE aliases: %s", got)
+	}
+	if got := strings.Join(aliases["RO"], ","); got != "germany inc" {
+		t.Errorf("unexpected RO aliases: %s", got)
+	}
+
+	for _, want := range []string{
+		`msg="data layer loaded" layer=company countries=2 added=1 updated=1`,
+		`msg="data layer overrides value" layer=company country=DE field=name_en previous=Germany value="Federal Republic of Germany"`,
+		`msg="data layer loaded" layer=team countries=1 added=0 updated=0 deleted=1`,
+	} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("expected log %q in:\n%s", want, logs.String())
+		}
+	}
+}
+
+func TestCompositeLoader_MergesMeta(t *testing.T) {
+	base := writeFile(t, "base.csv", strings.Join([]string{
+		"iso2,name_en,continent,capital,currencies",
+		"DE,Germany,Europe,Bonn,DEM",
+		"FR,France,,,",
+	}, "\n"))
+	company := writeFile(t, "company.csv", strings.Join([]string{
+		"iso2,name_en,capital,currencies,languages",
+		"DE,Germany,Berlin,EUR,de",
+		"FR,France,Paris,,",
+	}, "\n"))
+
+	var logs bytes.Buffer
+	loader := data.NewCompositeLoader([]data.Layer{
+		{Name: "base", Loader: data.NewCSVLoader(base, "", nil)},
+		{Name: "company", Loader: data.NewCSVLoader(company, "", nil)},
+	}, slog.New(slog.NewTextHandler(&logs, nil)))
+
+	countries, err := loader.LoadCountries()
+	if err != nil {
+		t.Fatalf("failed to load countries: %v", err)
+	}
+
+	germany := countries[0].Meta
+	if germany.Continent != "Europe" || germany.Capital != "Berlin" || strings.Join(germany.Currencies, ",") != "EUR" || strings.Join(germany.Languages, ",") != "de" {
+		t.Errorf("unexpected DE metadata: %+v", germany)
+	}
+	if france := countries[1].Meta; france == nil || france.Capital != "Paris" {
+		t.Errorf("unexpected FR metadata: %+v", france)
+	}
+
+	want := `msg="data layer overrides value" layer=company country=DE field=meta_capital previous=Bonn value=Berlin`
+	if !strings.Contains(logs.String(), want) {
+		t.Errorf("expected log %q in:\n%s", want, logs.String())
+	}
+}
+
+func TestCompositeLoader_AliasConflict(t *testing.T) {
+	first := writeFile(t, "first.csv", "iso2,name_en,aliases\nDE,Germany,allemagne\nFR,France,\n")
+	second := writeFile(t, "second.csv", "iso2,name_fr,aliases\nFR,France,allemagne\n")
+
+	var logs bytes.Buffer
+	loader := data.NewCompositeLoader([]data.Layer{
+		{Name: "first", Loader: data.NewCSVLoader(first, "", nil)},
+		{Name: "second", Loader: data.NewCSVLoader(second, "", nil)},
+	}, slog.New(slog.NewTextHandler(&logs, nil)))
+
+	if _, err := loader.LoadCountries(); err != nil {
+		t.Fatalf("failed to load countries: %v", err)
+	}
+	if !strings.Contains(logs.String(), `msg="data layer assigns alias to another country" layer=second alias=allemagne country=FR previous_country=DE`) {
+		t.Errorf("expected alias conflict to be reported:\n%s", logs.String())
+	}
+}
+
+func TestCompositeLoader_Errors(t *testing.T) {
+	loader := data.NewCompositeLoader([]data.Layer{
+		{Name: "broken", Loader: data.NewCSVLoader("/nonexistent/countries.csv", "", nil)},
+	}, slog.Default())
+	if _, err := loader.LoadCountries(); err == nil || !strings.Contains(err.Error(), "layer broken") {
+		t.Errorf("expected error naming the layer, got %v", err)
+	}
+
+	base := writeFile(t, "base.csv", "iso2,name_en\nDE,Germany\n")
+	cleanup := writeFile(t, "cleanup.csv", "iso2,name_en\nDE,Germany\n")
+	loader = data.NewCompositeLoader([]data.Layer{
+		{Name: "base", Loader: data.NewCSVLoader(base, "", nil)},
+		{Name: "cleanup", Loader: data.NewCSVLoader(cleanup, "", nil), Delete: []string{"DE"}},
+	}, slog.Default())
+	countries, err := loader.LoadCountries()
+	if err != nil {
+		t.Fatalf("failed to load countries: %v", err)
+	}
+	if len(countries) != 1 {
+		t.Errorf("expected a layer to define a country it deletes from earlier layers again, got %+v", countries)
+	}
+}
+
+// countingLoader counts how often its layer is loaded
+type countingLoader struct {
+	data.Loader
+	countries, aliases int
+}
+
+func (l *countingLoader) LoadCountries() ([]domain.Country, error) {
+	l.countries++
+	return l.Loader.LoadCountries()
+}
+
+func (l *countingLoader) LoadAliases() (map[string][]string, error) {
+	l.aliases++
+	return l.Loader.LoadAliases()
+}
+
+func TestCompositeLoader_LoadsLayersOnce(t *testing.T) {
+	base := &countingLoader{Loader: data.NewCSVLoader(writeFile(t, "base.csv", "iso2,name_en,aliases\nDE,Germany,deutschland\n"), "", nil)}
+	team := &countingLoader{Loader: data.NewCSVLoader(writeFile(t, "team.csv", "iso2,name_en,aliases\nFR,France,frankreich\n"), "", nil)}
+
+	var logs bytes.Buffer
+	loader := data.NewCompositeLoader([]data.Layer{
+		{Name: "base", Loader: base},
+		{Name: "team", Loader: team},
+	}, slog.New(slog.NewTextHandler(&logs, nil)))
+
+	loaded, err := data.Load(loader)
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if len(loaded.Countries) != 2 || len(loaded.Aliases["DE"]) != 1 || len(loaded.Aliases["FR"]) != 1 {
+		t.Errorf("unexpected data: %+v", loaded)
+	}
+	for _, layer := range []*countingLoader{base, team} {
+		if layer.countries != 1 || layer.aliases != 1 {
+			t.Errorf("expected each layer to be loaded once, got %d countries and %d aliases loads", layer.countries, layer.aliases)
+		}
+	}
+	if got := strings.Count(logs.String(), `msg="data layer loaded"`); got != 2 {
+		t.Errorf("expected one report per layer, got %d:\n%s", got, logs.String())
+	}
+
+	// A second build merges the layers again
+	if _, err := data.Load(loader); err != nil {
+		t.Fatalf("failed to reload: %v", err)
+	}
+	if base.countries != 2 || base.aliases != 2 {
+		t.Errorf("expected a second build to load the layer again, got %d/%d", base.countries, base.aliases)
+	}
+
+	// LoadAliases on its own does not depend on an earlier LoadCountries
+	aliases, err := loader.LoadAliases()
+	if err != nil || len(aliases["DE"]) != 1 || len(aliases["FR"]) != 1 {
+		t.Errorf("unexpected aliases: %v, %v", aliases, err)
+	}
+}
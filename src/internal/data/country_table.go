@@ -0,0 +1,454 @@
+package data
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+
+	"country-iso-matcher/src/internal/domain"
+)
+
+// Column roles of a countries table (CSV or TSV)
+const (
+	RoleISO2       = "iso2"
+	RoleISO3       = "iso3"
+	RoleNumeric    = "numeric"
+	RoleAliases    = "aliases"
+	RoleNamePrefix = "name_" // name_<lang>, e.g. name_en, name_pt-BR
+	RoleCodePrefix = "code_" // code_<system>, e.g. code_ioc, see domain.CodeSystems
+	RoleMetaPrefix = "meta_" // meta_<field>, e.g. meta_capital, see domain.MetaFields
+
+	RoleDemonymPrefix = "demonym_" // demonym_<lang>, e.g. demonym_fr, see domain.ParseDemonyms
+)
+
+// AliasSeparator separates the entries of an aliases column
+const AliasSeparator = "|"
+
+// headerRoles maps the header names recognized without a column mapping to their roles
+var headerRoles = map[string]string{
+	"iso2":         RoleISO2,
+	"code":         RoleISO2,
+	"iso":          RoleISO2,
+	"iso_code":     RoleISO2,
+	"country_code": RoleISO2,
+	"alpha2":       RoleISO2,
+	"alpha_2":      RoleISO2,
+	"iso3":         RoleISO3,
+	"alpha3":       RoleISO3,
+	"alpha_3":      RoleISO3,
+	"numeric":      RoleNumeric,
+	"iso_numeric":  RoleNumeric,
+	"numeric_code": RoleNumeric,
+	"m49":          RoleNumeric,
+	"aliases":      RoleAliases,
+	"alias":        RoleAliases,
+	"ioc":          RoleCodePrefix + domain.CodeSystemIOC,
+	"fifa":         RoleCodePrefix + domain.CodeSystemFIFA,
+	"itu":          RoleCodePrefix + domain.CodeSystemITU,
+	"calling_code": RoleCodePrefix + domain.CodeSystemITU,
+	"dialing_code": RoleCodePrefix + domain.CodeSystemITU,
+	"fips":         RoleCodePrefix + domain.CodeSystemFIPS,
+	"tld":          RoleCodePrefix + domain.CodeSystemTLD,
+	"cctld":        RoleCodePrefix + domain.CodeSystemTLD,
+	"vehicle":      RoleCodePrefix + domain.CodeSystemVehicle,
+	"continent":    RoleMetaPrefix + domain.MetaContinent,
+	"subregion":    RoleMetaPrefix + domain.MetaSubregion,
+	"capital":      RoleMetaPrefix + domain.MetaCapital,
+	"currency":     RoleMetaPrefix + domain.MetaCurrencies,
+	"currencies":   RoleMetaPrefix + domain.MetaCurrencies,
+	"languages":    RoleMetaPrefix + domain.MetaLanguages,
+	"demonym":      RoleDemonymPrefix + "en",
+	"nationality":  RoleDemonymPrefix + "en",
+	"name":         RoleNamePrefix + "en",
+	"country":      RoleNamePrefix + "en",
+	"country_name": RoleNamePrefix + "en",
+}
+
+// legacyRoles is the layout of countries files without a header row
+var legacyRoles = []string{RoleISO2, RoleNamePrefix + "en"}
+
+// readCountryTable reads a countries table whose columns are identified by the header row.
+// columns maps header names to roles (see the Role constants) and takes precedence over the
+// built-in header names. Files without a header are read as code,name.
+// Every invalid row fails the load with its row and column.
+func readCountryTable(path string, comma rune, columns map[string]string) ([]domain.Country, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open countries file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comma = comma
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1 // Row length is checked against the header below
+
+	name := filepath.Base(path)
+
+	first, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("countries file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	roles, isHeader, err := headerLayout(first, columns)
+	if err != nil {
+		return nil, fmt.Errorf("%s header: %w", name, err)
+	}
+
+	var countries []domain.Country
+	rowOf := make(map[string]int) // ISO2 -> line, to report duplicates
+
+	parse := func(record []string) error {
+		line, _ := reader.FieldPos(0)
+		country, err := parseCountryRow(record, roles, func(column int) string {
+			return fmt.Sprintf("%s row %d, column %d", name, line, column+1)
+		})
+		if err != nil || country == nil {
+			return err
+		}
+
+		if previous, exists := rowOf[country.ISO2]; exists {
+			return fmt.Errorf("%s row %d: duplicate country %s (first defined in row %d)", name, line, country.ISO2, previous)
+		}
+		rowOf[country.ISO2] = line
+
+		countries = append(countries, *country)
+		return nil
+	}
+
+	if !isHeader {
+		if err := parse(first); err != nil {
+			return nil, err
+		}
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		if err := parse(record); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(countries) == 0 {
+		return nil, fmt.Errorf("no valid countries found in file")
+	}
+
+	return countries, nil
+}
+
+// headerLayout returns the role of every column. The first row is a header when its first
+// cell names a role; a header must then name a role in every column and include iso2 and a name.
+func headerLayout(first []string, columns map[string]string) ([]string, bool, error) {
+	if _, ok, _ := columnRole(first[0], columns); !ok {
+		return legacyRoles, false, nil
+	}
+
+	roles := make([]string, len(first))
+	seen := make(map[string]int, len(first))
+	hasName := false
+
+	for i, header := range first {
+		role, ok, err := columnRole(header, columns)
+		if err != nil {
+			return nil, true, fmt.Errorf("column %d (%q): %w", i+1, header, err)
+		}
+		if !ok {
+			return nil, true, fmt.Errorf("column %d (%q) is not a known column; use iso2, iso3, numeric, aliases, name_<lang>, code_<system>, meta_<field>, demonym_<lang> or map it in data.columns", i+1, header)
+		}
+		if previous, exists := seen[role]; exists {
+			return nil, true, fmt.Errorf("columns %d and %d are both %s", previous, i+1, role)
+		}
+
+		seen[role] = i + 1
+		roles[i] = role
+		hasName = hasName || strings.HasPrefix(role, RoleNamePrefix)
+	}
+
+	if _, exists := seen[RoleISO2]; !exists {
+		return nil, true, fmt.Errorf("no iso2 column")
+	}
+	if !hasName {
+		return nil, true, fmt.Errorf("no name column (name or name_<lang>)")
+	}
+
+	return roles, true, nil
+}
+
+// columnRole resolves a header to its role through the configured mapping, then the
+// built-in header names and name_<lang>. It reports false for unknown headers.
+func columnRole(header string, columns map[string]string) (string, bool, error) {
+	header = strings.TrimSpace(header)
+
+	for name, role := range columns {
+		if strings.EqualFold(name, header) {
+			role, err := validateRole(role)
+			return role, err == nil, err
+		}
+	}
+
+	lower := strings.ToLower(header)
+	if role, ok := headerRoles[lower]; ok {
+		return role, true, nil
+	}
+	if strings.HasPrefix(lower, RoleNamePrefix) || strings.HasPrefix(lower, RoleCodePrefix) ||
+		strings.HasPrefix(lower, RoleMetaPrefix) || strings.HasPrefix(lower, RoleDemonymPrefix) {
+		role, err := validateRole(header)
+		return role, err == nil, err
+	}
+
+	return "", false, nil
+}
+
+// validateRole checks a role name, canonicalizes the language of name_<lang> and
+// demonym_<lang> and checks the system of code_<system> and the field of meta_<field>
+func validateRole(role string) (string, error) {
+	role = strings.TrimSpace(role)
+	lower := strings.ToLower(role)
+	switch lower {
+	case RoleISO2, RoleISO3, RoleNumeric, RoleAliases:
+		return lower, nil
+	}
+
+	if system, ok := strings.CutPrefix(lower, RoleCodePrefix); ok {
+		switch {
+		case system == domain.CodeSystemISO2, system == domain.CodeSystemISO3, system == domain.CodeSystemNumeric:
+			return system, nil
+		case domain.IsCodeSystem(system):
+			return lower, nil
+		default:
+			return "", fmt.Errorf("unknown code system in %q (must be one of %s)", role, strings.Join(domain.CodeSystems, ", "))
+		}
+	}
+
+	if field, ok := strings.CutPrefix(lower, RoleMetaPrefix); ok {
+		if !domain.IsMetaField(field) {
+			return "", fmt.Errorf("unknown metadata field in %q (must be one of %s)", role, strings.Join(domain.MetaFields, ", "))
+		}
+		return lower, nil
+	}
+
+	if len(role) > len(RoleDemonymPrefix) && strings.EqualFold(role[:len(RoleDemonymPrefix)], RoleDemonymPrefix) {
+		tag, err := language.Parse(role[len(RoleDemonymPrefix):])
+		if err != nil {
+			return "", fmt.Errorf("invalid language in %q: %w", role, err)
+		}
+		return RoleDemonymPrefix + tag.String(), nil
+	}
+
+	if len(role) > len(RoleNamePrefix) && strings.EqualFold(role[:len(RoleNamePrefix)], RoleNamePrefix) {
+		tag, err := language.Parse(role[len(RoleNamePrefix):])
+		if err != nil {
+			return "", fmt.Errorf("invalid language in %q: %w", role, err)
+		}
+		return RoleNamePrefix + tag.String(), nil
+	}
+
+	return "", fmt.Errorf("unknown column role %q", role)
+}
+
+// parseCountryRow converts one row into a country. Blank rows yield nil.
+// where describes a column's position for error messages.
+func parseCountryRow(record []string, roles []string, where func(column int) string) (*domain.Country, error) {
+	blank := true
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			blank = false
+			break
+		}
+	}
+	if blank {
+		return nil, nil
+	}
+
+	if len(record) > len(roles) {
+		return nil, fmt.Errorf("%s: unexpected extra column (expected %d columns)", where(len(roles)), len(roles))
+	}
+
+	country := &domain.Country{
+		Names:   make(map[string]string),
+		Aliases: []string{},
+	}
+
+	for i, role := range roles {
+		var value string
+		if i < len(record) {
+			value = strings.TrimSpace(record[i])
+		}
+
+		switch {
+		case role == RoleISO2:
+			if !isLetters(value, 2) {
+				return nil, fmt.Errorf("%s (iso2): %q is not a 2-letter code", where(i), value)
+			}
+			country.ISO2 = strings.ToUpper(value)
+
+		case role == RoleISO3:
+			if !isLetters(value, 3) {
+				return nil, fmt.Errorf("%s (iso3): %q is not a 3-letter code", where(i), value)
+			}
+			country.ISO3 = strings.ToUpper(value)
+
+		case role == RoleNumeric:
+			if value == "" {
+				continue
+			}
+			number, err := strconv.Atoi(value)
+			if err != nil || number < 0 || number > 999 {
+				return nil, fmt.Errorf("%s (numeric): %q is not a numeric code between 000 and 999", where(i), value)
+			}
+			country.Numeric = fmt.Sprintf("%03d", number)
+
+		case strings.HasPrefix(role, RoleCodePrefix):
+			if value == "" {
+				continue
+			}
+			system := strings.TrimPrefix(role, RoleCodePrefix)
+			code, ok := domain.NormalizeCode(system, value)
+			if !ok {
+				return nil, fmt.Errorf("%s (%s): %q is not a valid %s code", where(i), role, value, system)
+			}
+			if country.Codes == nil {
+				country.Codes = make(map[string]string)
+			}
+			country.Codes[system] = code
+
+		case strings.HasPrefix(role, RoleMetaPrefix):
+			if value == "" {
+				continue
+			}
+			if country.Meta == nil {
+				country.Meta = &domain.CountryMeta{}
+			}
+			if err := country.Meta.Set(strings.TrimPrefix(role, RoleMetaPrefix), value); err != nil {
+				return nil, fmt.Errorf("%s (%s): %w", where(i), role, err)
+			}
+
+		case strings.HasPrefix(role, RoleDemonymPrefix):
+			if value == "" {
+				continue
+			}
+			demonyms, err := domain.ParseDemonyms(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s (%s): %w", where(i), role, err)
+			}
+			if country.Demonyms == nil {
+				country.Demonyms = make(map[string][]domain.Demonym)
+			}
+			country.Demonyms[strings.TrimPrefix(role, RoleDemonymPrefix)] = demonyms
+
+		case role == RoleAliases:
+			for _, alias := range strings.Split(value, AliasSeparator) {
+				if alias = strings.TrimSpace(alias); alias != "" {
+					country.Aliases = append(country.Aliases, alias)
+				}
+			}
+
+		default: // name_<lang>
+			if value != "" {
+				country.Names[strings.TrimPrefix(role, RoleNamePrefix)] = value
+			}
+		}
+	}
+
+	if len(country.Names) == 0 {
+		return nil, fmt.Errorf("%s: country %s has no name", where(0), country.ISO2)
+	}
+	return country, nil
+}
+
+// isLetters reports whether value consists of exactly n ASCII letters
+func isLetters(value string, n int) bool {
+	if len(value) != n {
+		return false
+	}
+	for _, r := range value {
+		if (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') {
+			return false
+		}
+	}
+	return true
+}
+
+// readAliasTable reads an aliases file with one country per row: code, alias1, alias2, ...
+// An empty path means there is no aliases file.
+func readAliasTable(path string, comma rune) (map[string][]string, error) {
+	aliases := make(map[string][]string)
+	if path == "" {
+		return aliases, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open aliases file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comma = comma
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1 // Allow variable number of fields
+
+	// Read all records
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read aliases file: %w", err)
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("aliases file is empty")
+	}
+
+	// Check if first row is a header
+	startIdx := 0
+	if isHeader(records[0]) {
+		startIdx = 1
+	}
+
+	for i := startIdx; i < len(records); i++ {
+		record := records[i]
+		if len(record) < 2 {
+			continue // Skip invalid rows
+		}
+
+		code := strings.ToUpper(strings.TrimSpace(record[0]))
+		if code == "" {
+			continue
+		}
+
+		// Collect all aliases for this country
+		for j := 1; j < len(record); j++ {
+			alias := strings.TrimSpace(record[j])
+			if alias != "" {
+				aliases[code] = append(aliases[code], alias)
+			}
+		}
+	}
+
+	return aliases, nil
+}
+
+// tableAliases combines the aliases columns of the countries table with the aliases file
+func tableAliases(countries []domain.Country, aliases map[string][]string) map[string][]string {
+	for _, country := range countries {
+		if len(country.Aliases) > 0 {
+			aliases[country.ISO2] = append(append([]string{}, country.Aliases...), aliases[country.ISO2]...)
+		}
+	}
+	return aliases
+}
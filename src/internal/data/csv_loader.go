@@ -1,9 +1,6 @@
 package data
 
 import (
-	"encoding/csv"
-	"fmt"
-	"os"
 	"strings"
 
 	"country-iso-matcher/src/internal/domain"
@@ -13,151 +10,66 @@ import (
 type CSVLoader struct {
 	countriesFile string
 	aliasesFile   string
+	columns       map[string]string
 }
 
-// NewCSVLoader creates a new CSV loader
-func NewCSVLoader(countriesFile, aliasesFile string) *CSVLoader {
+// NewCSVLoader creates a new CSV loader. columns maps header names of the countries file
+// to column roles; it may be nil when the file uses the standard header names.
+func NewCSVLoader(countriesFile, aliasesFile string, columns map[string]string) *CSVLoader {
 	return &CSVLoader{
 		countriesFile: countriesFile,
 		aliasesFile:   aliasesFile,
+		columns:       columns,
 	}
 }
 
-// LoadCountries loads countries from a CSV file
-// Expected format: code,name
-// Example: US,United States
+// LoadCountries loads countries from a CSV file with a header naming each column's role
+// Example:
+//
+//	iso2,iso3,numeric,name_en,name_de,aliases
+//	DE,DEU,276,Germany,Deutschland,deutchland|germania
+//
+// Files without a header are read as code,name.
 func (l *CSVLoader) LoadCountries() ([]domain.Country, error) {
-	file, err := os.Open(l.countriesFile)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open countries file: %w", err)
-	}
-	defer file.Close()
-
-	reader := csv.NewReader(file)
-	reader.TrimLeadingSpace = true
-
-	// Read all records
-	records, err := reader.ReadAll()
-	if err != nil {
-		return nil, fmt.Errorf("failed to read countries CSV: %w", err)
-	}
-
-	if len(records) == 0 {
-		return nil, fmt.Errorf("countries file is empty")
-	}
-
-	countries := make([]domain.Country, 0, len(records))
-
-	// Check if first row is a header
-	startIdx := 0
-	if isHeader(records[0]) {
-		startIdx = 1
-	}
-
-	for i := startIdx; i < len(records); i++ {
-		record := records[i]
-		if len(record) < 2 {
-			continue // Skip invalid rows
-		}
-
-		code := strings.TrimSpace(record[0])
-		name := strings.TrimSpace(record[1])
-
-		if code == "" || name == "" {
-			continue // Skip empty entries
-		}
-
-		countries = append(countries, domain.Country{
-			ISO2: code,
-			ISO3: code, // Fallback: use ISO2 if ISO3 not available
-			Names: map[string]string{
-				"en": name,
-			},
-			Aliases: []string{},
-		})
-	}
-
-	if len(countries) == 0 {
-		return nil, fmt.Errorf("no valid countries found in file")
-	}
-
-	return countries, nil
+	return readCountryTable(l.countriesFile, ',', l.columns)
 }
 
-// LoadAliases loads country aliases from a CSV file
-// Expected format: code,alias1,alias2,alias3,...
+// LoadAliases loads the aliases column of the countries file and the aliases file
+// Expected aliases file format: code,alias1,alias2,alias3,...
 // Example: US,usa,america,united states,estados unidos
 func (l *CSVLoader) LoadAliases() (map[string][]string, error) {
-	file, err := os.Open(l.aliasesFile)
+	countries, err := l.LoadCountries()
 	if err != nil {
-		return nil, fmt.Errorf("failed to open aliases file: %w", err)
+		return nil, err
 	}
-	defer file.Close()
-
-	reader := csv.NewReader(file)
-	reader.TrimLeadingSpace = true
-	reader.FieldsPerRecord = -1 // Allow variable number of fields
 
-	// Read all records
-	records, err := reader.ReadAll()
+	aliases, err := readAliasTable(l.aliasesFile, ',')
 	if err != nil {
-		return nil, fmt.Errorf("failed to read aliases CSV: %w", err)
-	}
-
-	if len(records) == 0 {
-		return nil, fmt.Errorf("aliases file is empty")
+		return nil, err
 	}
 
-	aliases := make(map[string][]string)
-
-	// Check if first row is a header
-	startIdx := 0
-	if isHeader(records[0]) {
-		startIdx = 1
-	}
-
-	for i := startIdx; i < len(records); i++ {
-		record := records[i]
-		if len(record) < 2 {
-			continue // Skip invalid rows
-		}
-
-		code := strings.TrimSpace(record[0])
-		if code == "" {
-			continue
-		}
-
-		// Collect all aliases for this country
-		countryAliases := make([]string, 0, len(record)-1)
-		for j := 1; j < len(record); j++ {
-			alias := strings.TrimSpace(record[j])
-			if alias != "" {
-				countryAliases = append(countryAliases, alias)
-			}
-		}
+	return tableAliases(countries, aliases), nil
+}
 
-		if len(countryAliases) > 0 {
-			aliases[code] = countryAliases
-		}
-	}
+// headerCells are the column names that mark a row as a header. None of them is a country
+// code, so a data row of an aliases file never looks like a header.
+var headerCells = map[string]bool{
+	"code":         true,
+	"iso":          true,
+	"iso_code":     true,
+	"country_code": true,
+	"country":      true,
+	"country_name": true,
+	"name":         true,
+}
 
-	return aliases, nil
+// IsHeaderCell reports whether a cell is a column name of the country code or name column,
+// marking its row as a header
+func IsHeaderCell(cell string) bool {
+	return headerCells[strings.ToLower(strings.TrimSpace(cell))]
 }
 
 // isHeader checks if a record looks like a header row
 func isHeader(record []string) bool {
-	if len(record) == 0 {
-		return false
-	}
-
-	// Common header patterns
-	firstField := strings.ToLower(strings.TrimSpace(record[0]))
-	headers := map[string]bool{
-		"code":         true,
-		"iso":          true,
-		"iso_code":     true,
-		"country_code": true,
-	}
-
-	return headers[firstField]
+	return len(record) > 0 && IsHeaderCell(record[0])
 }
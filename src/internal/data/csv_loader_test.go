@@ -0,0 +1,231 @@
+package data_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"country-iso-matcher/src/internal/data"
+	"country-iso-matcher/src/internal/domain"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestCSVLoader_HeaderColumns(t *testing.T) {
+	countries := writeFile(t, "countries.csv", strings.Join([]string{
+		"iso2,iso3,numeric,name_en,name_de,name_pt-br,aliases",
+		"de,DEU,276,Germany,Deutschland,Alemanha,deutchland|germania",
+		"",
+		"RO,ROU,642,Romania,Rumänien,,",
+	}, "\n"))
+	aliases := writeFile(t, "aliases.csv", "code,alias1\nDE,allemagne\n")
+
+	loader := data.NewCSVLoader(countries, aliases, nil)
+
+	loaded, err := loader.LoadCountries()
+	if err != nil {
+		t.Fatalf("failed to load countries: %v", err)
+	}
+	if len(loaded) != 2 {
+		t.Fatalf("expected 2 countries, got %d", len(loaded))
+	}
+
+	germany := loaded[0]
+	if germany.ISO2 != "DE" || germany.ISO3 != "DEU" || germany.Numeric != "276" {
+		t.Errorf("unexpected codes: %+v", germany)
+	}
+	if germany.Names["de"] != "Deutschland" || germany.Names["pt-BR"] != "Alemanha" {
+		t.Errorf("unexpected names: %v", germany.Names)
+	}
+	if _, ok := loaded[1].Names["pt-BR"]; ok {
+		t.Errorf("expected empty name cell to be skipped: %v", loaded[1].Names)
+	}
+
+	loadedAliases, err := loader.LoadAliases()
+	if err != nil {
+		t.Fatalf("failed to load aliases: %v", err)
+	}
+	if got := strings.Join(loadedAliases["DE"], ","); got != "deutchland,germania,allemagne" {
+		t.Errorf("unexpected aliases: %s", got)
+	}
+}
+
+func TestTSVLoader_ColumnMapping(t *testing.T) {
+	countries := writeFile(t, "countries.tsv", "Country Code\tAlpha-3\tLabel\nRO\tROU\tRomania\n")
+
+	loader := data.NewTSVLoader(countries, "", map[string]string{
+		"country code": "iso2",
+		"Alpha-3":      "iso3",
+		"Label":        "name_en",
+	})
+
+	loaded, err := loader.LoadCountries()
+	if err != nil {
+		t.Fatalf("failed to load countries: %v", err)
+	}
+	if len(loaded) != 1 || loaded[0].ISO3 != "ROU" || loaded[0].Names["en"] != "Romania" {
+		t.Errorf("unexpected countries: %+v", loaded)
+	}
+}
+
+func TestCSVLoader_LegacyLayout(t *testing.T) {
+	countries := writeFile(t, "countries.csv", "US,United States\nRO,Romania\n")
+
+	loaded, err := data.NewCSVLoader(countries, "", nil).LoadCountries()
+	if err != nil {
+		t.Fatalf("failed to load countries: %v", err)
+	}
+	if len(loaded) != 2 || loaded[1].ISO2 != "RO" || loaded[1].Names["en"] != "Romania" {
+		t.Errorf("unexpected countries: %+v", loaded)
+	}
+	// Without an iso3 column the alpha-2 code must not stand in for ISO3
+	if loaded[1].ISO3 != "" {
+		t.Errorf("expected empty ISO3, got %q", loaded[1].ISO3)
+	}
+}
+
+func TestCSVLoader_InvalidRows(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{name: "bad iso3", content: "iso2,iso3,name\nRO,ROU1,Romania\n", expected: "row 2, column 2 (iso3)"},
+		{name: "bad numeric", content: "iso2,numeric,name\nRO,abc,Romania\n", expected: "row 2, column 2 (numeric)"},
+		{name: "missing name", content: "iso2,name_en,name_de\nRO,,\n", expected: "row 2, column 1: country RO has no name"},
+		{name: "extra column", content: "iso2,name\nRO,Romania,x\n", expected: "row 2, column 3: unexpected extra column"},
+		{name: "duplicate", content: "iso2,name\nRO,Romania\nro,Romania\n", expected: "row 3: duplicate country RO (first defined in row 2)"},
+		{name: "unknown header", content: "iso2,name,population\nRO,Romania,19\n", expected: `column 3 ("population") is not a known column`},
+		{name: "no iso2", content: "iso3,name\nROU,Romania\n", expected: "no iso2 column"},
+		{name: "bad language", content: "iso2,name_xx-!!\nRO,Romania\n", expected: "invalid language"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			countries := writeFile(t, "countries.csv", tt.content)
+
+			_, err := data.NewCSVLoader(countries, "", nil).LoadCountries()
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestCSVLoader_CodeColumns(t *testing.T) {
+	countries := writeFile(t, "countries.csv", strings.Join([]string{
+		"iso2,name_en,ioc,calling_code,code_tld,Plate",
+		"DE,Germany,ger,+49,DE,D",
+	}, "\n"))
+
+	loaded, err := data.NewCSVLoader(countries, "", map[string]string{"plate": "code_vehicle"}).LoadCountries()
+	if err != nil {
+		t.Fatalf("failed to load countries: %v", err)
+	}
+
+	expected := map[string]string{"ioc": "GER", "itu": "49", "tld": ".de", "vehicle": "D"}
+	for system, code := range expected {
+		if loaded[0].Codes[system] != code {
+			t.Errorf("expected %s code %s, got %v", system, code, loaded[0].Codes)
+		}
+	}
+
+	invalid := writeFile(t, "invalid.csv", "iso2,name_en,code_nato\nDE,Germany,GE\n")
+	if _, err := data.NewCSVLoader(invalid, "", nil).LoadCountries(); err == nil || !strings.Contains(err.Error(), "unknown code system") {
+		t.Errorf("expected unknown code system to be rejected, got %v", err)
+	}
+}
+
+func TestCSVLoader_MetaColumns(t *testing.T) {
+	countries := writeFile(t, "countries.csv", strings.Join([]string{
+		"iso2,name_en,continent,meta_subregion,capital,currencies,meta_languages,code_itu",
+		"CH,Switzerland,Europe,Western Europe,Bern,chf,de|FR| it |rm,41",
+		"LI,Liechtenstein,,,,,,",
+	}, "\n"))
+
+	loaded, err := data.NewCSVLoader(countries, "", nil).LoadCountries()
+	if err != nil {
+		t.Fatalf("failed to load countries: %v", err)
+	}
+
+	meta := loaded[0].Meta
+	if meta == nil || meta.Continent != "Europe" || meta.Subregion != "Western Europe" || meta.Capital != "Bern" {
+		t.Fatalf("unexpected metadata: %+v", meta)
+	}
+	if strings.Join(meta.Currencies, ",") != "CHF" || strings.Join(meta.Languages, ",") != "de,fr,it,rm" {
+		t.Errorf("unexpected currencies or languages: %v %v", meta.Currencies, meta.Languages)
+	}
+	if loaded[1].Meta != nil {
+		t.Errorf("expected no metadata for empty cells, got %+v", loaded[1].Meta)
+	}
+
+	for name, content := range map[string]string{
+		"field.csv":    "iso2,name_en,meta_population\nCH,Switzerland,9000000\n",
+		"currency.csv": "iso2,name_en,currencies\nCH,Switzerland,Swiss franc\n",
+	} {
+		invalid := writeFile(t, name, content)
+		if _, err := data.NewCSVLoader(invalid, "", nil).LoadCountries(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestCSVLoader_DemonymColumns(t *testing.T) {
+	countries := writeFile(t, "countries.csv", strings.Join([]string{
+		"iso2,name_en,nationality,demonym_FR",
+		`FR,France,French,Français (m)|Française (f)|Françaises (f pl)`,
+		"LI,Liechtenstein,,",
+	}, "\n"))
+
+	loaded, err := data.NewCSVLoader(countries, "", nil).LoadCountries()
+	if err != nil {
+		t.Fatalf("failed to load countries: %v", err)
+	}
+
+	demonyms := loaded[0].Demonyms
+	if len(demonyms["en"]) != 1 || demonyms["en"][0].Text != "French" {
+		t.Errorf("unexpected English demonyms: %+v", demonyms["en"])
+	}
+	expected := []domain.Demonym{
+		{Text: "Français", Gender: domain.GenderMasculine},
+		{Text: "Française", Gender: domain.GenderFeminine},
+		{Text: "Françaises", Gender: domain.GenderFeminine, Plural: true},
+	}
+	if !slices.Equal(demonyms["fr"], expected) {
+		t.Errorf("expected %+v, got %+v", expected, demonyms["fr"])
+	}
+	if loaded[1].Demonyms != nil {
+		t.Errorf("expected no demonyms for empty cells, got %+v", loaded[1].Demonyms)
+	}
+
+	invalid := writeFile(t, "tag.csv", "iso2,name_en,demonym_fr\nFR,France,Français (x)\n")
+	if _, err := data.NewCSVLoader(invalid, "", nil).LoadCountries(); err == nil {
+		t.Error("expected an error for an unknown tag")
+	}
+}
+
+func TestIsHeaderCell(t *testing.T) {
+	for cell, want := range map[string]bool{
+		"code":          true,
+		" Country_Code": true,
+		"Country":       true,
+		"name":          true,
+		"DE":            false,
+		"Germany":       false,
+		"":              false,
+	} {
+		if got := data.IsHeaderCell(cell); got != want {
+			t.Errorf("IsHeaderCell(%q) = %v, want %v", cell, got, want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package data
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	"country-iso-matcher/src/internal/domain"
+)
+
+//go:generate go run ../../cmd/gendata -data ../../../data -out embedded/countries.json -historical-out embedded/historical.json -subdivisions-out embedded/subdivisions.json -postal-codes-out embedded/postal_codes.json
+
+// embeddedCountries is the reference dataset compiled into the binary: all 249 ISO 3166-1
+// countries with alpha-3 and numeric codes, names in ten languages, common aliases and metadata
+//
+//go:embed embedded/countries.json
+var embeddedCountries []byte
+
+// EmbeddedLoader loads the reference dataset compiled into the binary.
+// It needs no external files, so it is the default data source.
+type EmbeddedLoader struct{}
+
+// NewEmbeddedLoader creates a new embedded loader
+func NewEmbeddedLoader() *EmbeddedLoader {
+	return &EmbeddedLoader{}
+}
+
+// LoadCountries returns the embedded countries
+func (l *EmbeddedLoader) LoadCountries() ([]domain.Country, error) {
+	// Decode on every call so callers never share the names and aliases they may modify
+	var countries []domain.Country
+	if err := json.Unmarshal(embeddedCountries, &countries); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded country data: %w", err)
+	}
+	return countries, nil
+}
+
+// LoadAliases returns the aliases of the embedded countries
+func (l *EmbeddedLoader) LoadAliases() (map[string][]string, error) {
+	countries, err := l.LoadCountries()
+	if err != nil {
+		return nil, err
+	}
+
+	aliases := make(map[string][]string)
+	for _, country := range countries {
+		if len(country.Aliases) > 0 {
+			aliases[country.ISO2] = country.Aliases
+		}
+	}
+	return aliases, nil
+}
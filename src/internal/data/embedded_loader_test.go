@@ -0,0 +1,85 @@
+package data_test
+
+import (
+	"testing"
+
+	"country-iso-matcher/src/internal/data"
+)
+
+func TestEmbeddedLoader_CompleteDataset(t *testing.T) {
+	loader := data.NewEmbeddedLoader()
+
+	countries, err := loader.LoadCountries()
+	if err != nil {
+		t.Fatalf("failed to load countries: %v", err)
+	}
+	if len(countries) != 249 {
+		t.Fatalf("expected 249 ISO 3166-1 countries, got %d", len(countries))
+	}
+
+	languages := []string{"en", "es", "fr", "de", "zh", "ja", "ar", "ru", "pt", "it"}
+	seen := make(map[string]bool)
+	for _, country := range countries {
+		for _, code := range []string{country.ISO2, country.ISO3, country.Numeric} {
+			if seen[code] {
+				t.Errorf("duplicate code %s", code)
+			}
+			seen[code] = true
+		}
+		if len(country.ISO2) != 2 || len(country.ISO3) != 3 || len(country.Numeric) != 3 {
+			t.Errorf("unexpected codes: %s %s %s", country.ISO2, country.ISO3, country.Numeric)
+		}
+		if country.Meta == nil || country.Meta.Continent == "" || country.Meta.Region == "" {
+			t.Errorf("%s: missing continent or region", country.ISO2)
+		}
+		for _, lang := range languages {
+			if country.Names[lang] == "" {
+				t.Errorf("%s: missing %s name", country.ISO2, lang)
+			}
+		}
+	}
+
+	tests := []struct {
+		iso2, iso3, numeric, lang, name string
+	}{
+		{"DE", "DEU", "276", "de", "Deutschland"},
+		{"AX", "ALA", "248", "en", "Åland Islands"},
+		{"SS", "SSD", "728", "fr", "Soudan du Sud"},
+		{"PM", "SPM", "666", "en", "Saint Pierre and Miquelon"},
+		{"CD", "COD", "180", "en", "Congo, Democratic Republic of the"},
+		{"TZ", "TZA", "834", "en", "Tanzania, United Republic of"},
+	}
+	for _, tt := range tests {
+		found := false
+		for _, country := range countries {
+			if country.ISO2 != tt.iso2 {
+				continue
+			}
+			found = true
+			if country.ISO3 != tt.iso3 || country.Numeric != tt.numeric || country.Names[tt.lang] != tt.name {
+				t.Errorf("unexpected %s: %s %s %q", tt.iso2, country.ISO3, country.Numeric, country.Names[tt.lang])
+			}
+		}
+		if !found {
+			t.Errorf("missing %s", tt.iso2)
+		}
+	}
+
+	for _, country := range countries {
+		if country.ISO2 != "CH" {
+			continue
+		}
+		meta := country.Meta
+		if meta.Capital != "Bern" || meta.Subregion != "Western Europe" || len(meta.Currencies) != 1 || len(meta.Languages) != 4 {
+			t.Errorf("unexpected metadata for CH: %+v", meta)
+		}
+	}
+
+	aliases, err := loader.LoadAliases()
+	if err != nil {
+		t.Fatalf("failed to load aliases: %v", err)
+	}
+	if len(aliases["US"]) == 0 || len(aliases["DE"]) == 0 {
+		t.Errorf("expected common aliases, got US=%v DE=%v", aliases["US"], aliases["DE"])
+	}
+}
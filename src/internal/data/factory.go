@@ -2,13 +2,32 @@ package data
 
 import (
 	"fmt"
+	"log/slog"
+	"path/filepath"
 
 	"country-iso-matcher/src/internal/config"
 )
 
-// NewLoader creates the appropriate data loader based on configuration
-func NewLoader(cfg *config.DataConfig) (Loader, error) {
+// NewLoader creates the appropriate data loader based on configuration.
+// The loader also provides the historical countries of cfg.HistoricalFile, the
+// subdivisions of cfg.SubdivisionsFile and the postal code formats of cfg.PostalCodesFile,
+// or the embedded data when no file is configured (see HistoricalLoader, SubdivisionLoader
+// and PostalCodeLoader).
+func NewLoader(cfg *config.DataConfig, db *config.DatabaseConfig, logger *slog.Logger) (Loader, error) {
+	loader, err := newSourceLoader(cfg, db, logger)
+	if err != nil {
+		return nil, err
+	}
+	loader = WithSubdivisions(WithHistorical(loader, cfg.HistoricalFile), cfg.SubdivisionsFile)
+	return WithPostalCodes(loader, cfg.PostalCodesFile), nil
+}
+
+// newSourceLoader creates the loader of current countries for a data source
+func newSourceLoader(cfg *config.DataConfig, db *config.DatabaseConfig, logger *slog.Logger) (Loader, error) {
 	switch cfg.Source {
+	case "embedded":
+		return NewEmbeddedLoader(), nil
+
 	case "memory":
 		return NewMemoryLoader(), nil
 
@@ -19,21 +38,89 @@ func NewLoader(cfg *config.DataConfig) (Loader, error) {
 		return NewJSONLoader(cfg.CountriesDir), nil
 
 	case "csv":
-		if cfg.CountriesFile == "" || cfg.AliasesFile == "" {
-			return nil, fmt.Errorf("countries_file and aliases_file must be specified for CSV source")
+		if cfg.CountriesFile == "" {
+			return nil, fmt.Errorf("countries_file must be specified for CSV source")
 		}
-		return NewCSVLoader(cfg.CountriesFile, cfg.AliasesFile), nil
+		return NewCSVLoader(cfg.CountriesFile, cfg.AliasesFile, cfg.Columns), nil
 
 	case "tsv":
-		if cfg.CountriesFile == "" || cfg.AliasesFile == "" {
-			return nil, fmt.Errorf("countries_file and aliases_file must be specified for TSV source")
+		if cfg.CountriesFile == "" {
+			return nil, fmt.Errorf("countries_file must be specified for TSV source")
 		}
-		return NewTSVLoader(cfg.CountriesFile, cfg.AliasesFile), nil
+		return NewTSVLoader(cfg.CountriesFile, cfg.AliasesFile, cfg.Columns), nil
 
 	case "database":
-		return nil, fmt.Errorf("database loader not yet implemented - will be available with database repository")
+		if db == nil || !db.Enabled {
+			return nil, fmt.Errorf("database must be enabled for database source")
+		}
+		return NewSQLLoader(db)
+
+	case "composite":
+		if len(cfg.Layers) == 0 {
+			return nil, fmt.Errorf("layers must be specified for composite source")
+		}
+
+		layers := make([]Layer, 0, len(cfg.Layers))
+		for i := range cfg.Layers {
+			layerCfg := cfg.Layers[i].DataConfig()
+			if layerCfg.Source == "composite" {
+				return nil, fmt.Errorf("layer %d: composite sources cannot be nested", i+1)
+			}
+
+			loader, err := newSourceLoader(&layerCfg, db, logger)
+			if err != nil {
+				return nil, fmt.Errorf("layer %d (%s): %w", i+1, cfg.Layers[i].LayerName(), err)
+			}
+
+			layers = append(layers, Layer{
+				Name:          cfg.Layers[i].LayerName(),
+				Loader:        loader,
+				Delete:        cfg.Layers[i].Delete,
+				DeleteAliases: cfg.Layers[i].DeleteAliases,
+			})
+		}
+		return NewCompositeLoader(layers, logger), nil
+
+	default:
+		return nil, fmt.Errorf("unknown data source: %s (must be embedded, json, memory, csv, tsv, database, or composite)", cfg.Source)
+	}
+}
+
+// WatchPatterns returns the glob patterns of the files a data source reads, including
+// the historical countries, subdivisions and postal codes files, so they can be watched for changes.
+// Sources without files return nil.
+func WatchPatterns(cfg *config.DataConfig) []string {
+	patterns := sourcePatterns(cfg)
+	if cfg.HistoricalFile != "" {
+		patterns = append(patterns, cfg.HistoricalFile)
+	}
+	if cfg.SubdivisionsFile != "" {
+		patterns = append(patterns, cfg.SubdivisionsFile)
+	}
+	if cfg.PostalCodesFile != "" {
+		patterns = append(patterns, cfg.PostalCodesFile)
+	}
+	return patterns
+}
 
+// sourcePatterns returns the glob patterns of the country files of a data source
+func sourcePatterns(cfg *config.DataConfig) []string {
+	switch cfg.Source {
+	case "json":
+		return []string{filepath.Join(cfg.CountriesDir, "*.json")}
+	case "csv", "tsv":
+		if cfg.AliasesFile == "" {
+			return []string{cfg.CountriesFile}
+		}
+		return []string{cfg.CountriesFile, cfg.AliasesFile}
+	case "composite":
+		var patterns []string
+		for i := range cfg.Layers {
+			layerCfg := cfg.Layers[i].DataConfig()
+			patterns = append(patterns, sourcePatterns(&layerCfg)...)
+		}
+		return patterns
 	default:
-		return nil, fmt.Errorf("unknown data source: %s (must be json, memory, csv, tsv, or database)", cfg.Source)
+		return nil
 	}
 }
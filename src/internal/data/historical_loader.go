@@ -0,0 +1,49 @@
+package data
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	"country-iso-matcher/src/internal/domain"
+)
+
+// embeddedHistorical is the list of ISO 3166-3 withdrawn countries compiled into the binary,
+// generated from data/historical.json
+//
+//go:embed embedded/historical.json
+var embeddedHistorical []byte
+
+// ParseHistorical decodes a JSON array of historical countries and checks the required
+// fields. Numeric codes are normalized to three digits; source names the input in errors.
+func ParseHistorical(content []byte, source string) ([]domain.HistoricalCountry, error) {
+	var countries []domain.HistoricalCountry
+	if err := json.Unmarshal(content, &countries); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON in %s: %w", source, err)
+	}
+
+	for i := range countries {
+		country := &countries[i]
+		if !domain.IsHistoricalCode(country.Code) {
+			return nil, fmt.Errorf("invalid ISO 3166-3 code %q in %s", country.Code, source)
+		}
+		if len(country.ISO2) != 2 || len(country.ISO3) != 3 {
+			return nil, fmt.Errorf("historical country %s has malformed ISO codes %q/%q in %s", country.Code, country.ISO2, country.ISO3, source)
+		}
+		if len(country.Names) == 0 {
+			return nil, fmt.Errorf("historical country %s has no names in %s", country.Code, source)
+		}
+		if country.ValidTo == "" {
+			return nil, fmt.Errorf("historical country %s has no validTo in %s", country.Code, source)
+		}
+		if country.Numeric != "" {
+			numeric, ok := domain.NumericCode(country.Numeric)
+			if !ok {
+				return nil, fmt.Errorf("invalid numeric code %q for %s in %s", country.Numeric, country.Code, source)
+			}
+			country.Numeric = numeric
+		}
+	}
+
+	return countries, nil
+}
@@ -0,0 +1,81 @@
+package data_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"country-iso-matcher/src/internal/data"
+)
+
+func TestWithHistorical(t *testing.T) {
+	loader, ok := data.WithHistorical(data.NewMemoryLoader(), "").(data.HistoricalLoader)
+	if !ok {
+		t.Fatal("expected the loader to provide historical countries")
+	}
+
+	historical, err := loader.LoadHistorical()
+	if err != nil {
+		t.Fatalf("failed to load embedded historical countries: %v", err)
+	}
+	found := make(map[string]bool)
+	for _, country := range historical {
+		found[country.Code] = true
+		if len(country.Successors) == 0 {
+			t.Errorf("%s: no successors", country.Code)
+		}
+	}
+	for _, code := range []string{"SUHH", "YUCS", "CSHH", "ZRCD", "DDDE"} {
+		if !found[code] {
+			t.Errorf("missing historical country %s", code)
+		}
+	}
+
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		content string
+		valid   bool
+	}{
+		{
+			name:    "valid",
+			content: `[{"code": "SUHH", "iso2": "SU", "iso3": "SUN", "numeric": "810", "names": {"en": "USSR"}, "validTo": "1992", "successors": ["RU"]}]`,
+			valid:   true,
+		},
+		{
+			name:    "malformed code",
+			content: `[{"code": "SU", "iso2": "SU", "iso3": "SUN", "names": {"en": "USSR"}, "validTo": "1992"}]`,
+		},
+		{
+			name:    "missing validTo",
+			content: `[{"code": "SUHH", "iso2": "SU", "iso3": "SUN", "names": {"en": "USSR"}}]`,
+		},
+		{
+			name:    "invalid numeric",
+			content: `[{"code": "SUHH", "iso2": "SU", "iso3": "SUN", "numeric": "8100", "names": {"en": "USSR"}, "validTo": "1992"}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(dir, tt.name+".json")
+			if err := os.WriteFile(file, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			historical, err := data.WithHistorical(data.NewMemoryLoader(), file).(data.HistoricalLoader).LoadHistorical()
+			if !tt.valid {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(historical) != 1 || historical[0].Code != "SUHH" {
+				t.Errorf("unexpected historical countries: %+v", historical)
+			}
+		})
+	}
+}
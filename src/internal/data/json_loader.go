@@ -55,6 +55,28 @@ func (l *JSONLoader) LoadCountries() ([]domain.Country, error) {
 		if len(country.Names) == 0 {
 			return nil, fmt.Errorf("missing names in %s", file)
 		}
+		if country.Numeric != "" {
+			numeric, ok := domain.NumericCode(country.Numeric)
+			if !ok {
+				return nil, fmt.Errorf("invalid numeric code %q in %s", country.Numeric, file)
+			}
+			country.Numeric = numeric
+		}
+		for system, value := range country.Codes {
+			code, ok := domain.NormalizeCode(system, value)
+			if !domain.IsCodeSystem(system) || !ok {
+				return nil, fmt.Errorf("invalid %s code %q in %s", system, value, file)
+			}
+			country.Codes[system] = code
+		}
+		if country.Meta != nil {
+			if err := country.Meta.Normalize(); err != nil {
+				return nil, fmt.Errorf("invalid meta in %s: %w", file, err)
+			}
+		}
+		if err := domain.NormalizeDemonyms(country.Demonyms); err != nil {
+			return nil, fmt.Errorf("invalid demonyms in %s: %w", file, err)
+		}
 
 		countries = append(countries, country)
 	}
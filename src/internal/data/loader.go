@@ -11,14 +11,29 @@ type Loader interface {
 	LoadAliases() (map[string][]string, error)
 }
 
+// SnapshotLoader is implemented by loaders that load countries and aliases together, so
+// both come from one read of their sources
+type SnapshotLoader interface {
+	Load() ([]domain.Country, map[string][]string, error)
+}
+
 // CountryData represents the complete country dataset
 type CountryData struct {
 	Countries []domain.Country
 	Aliases   map[string][]string
 }
 
-// Load is a helper function to load both countries and aliases
+// Load is a helper function to load both countries and aliases, in one call when the
+// loader is a SnapshotLoader
 func Load(loader Loader) (*CountryData, error) {
+	if snapshot, ok := loader.(SnapshotLoader); ok {
+		countries, aliases, err := snapshot.Load()
+		if err != nil {
+			return nil, err
+		}
+		return &CountryData{Countries: countries, Aliases: aliases}, nil
+	}
+
 	countries, err := loader.LoadCountries()
 	if err != nil {
 		return nil, err
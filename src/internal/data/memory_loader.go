@@ -22,10 +22,11 @@ func (l *MemoryLoader) LoadAliases() (map[string][]string, error) {
 }
 
 // newCountry is a helper to create a country with the new structure
-func newCountry(iso2, iso3, name string) domain.Country {
+func newCountry(iso2, iso3, numeric, name string) domain.Country {
 	return domain.Country{
 		ISO2:    iso2,
 		ISO3:    iso3,
+		Numeric: numeric,
 		Names:   map[string]string{"en": name},
 		Aliases: []string{},
 	}
@@ -34,103 +35,103 @@ func newCountry(iso2, iso3, name string) domain.Country {
 // getCountryData returns hardcoded country data
 func getCountryData() []domain.Country {
 	return []domain.Country{
-		newCountry("AF", "AFG", "Afghanistan"), newCountry("AL", "ALB", "Albania"),
-		newCountry("DZ", "DZA", "Algeria"), newCountry("AD", "AND", "Andorra"),
-		newCountry("AO", "AGO", "Angola"), newCountry("AG", "ATG", "Antigua and Barbuda"),
-		newCountry("AR", "ARG", "Argentina"), newCountry("AM", "ARM", "Armenia"),
-		newCountry("AU", "AUS", "Australia"), newCountry("AT", "AUT", "Austria"),
-		newCountry("AZ", "AZE", "Azerbaijan"), newCountry("BS", "BHS", "Bahamas"),
-		newCountry("BH", "BHR", "Bahrain"), newCountry("BD", "BGD", "Bangladesh"),
-		newCountry("BB", "BRB", "Barbados"), newCountry("BY", "BLR", "Belarus"),
-		newCountry("BE", "BEL", "Belgium"), newCountry("BZ", "BLZ", "Belize"),
-		newCountry("BJ", "BEN", "Benin"), newCountry("BT", "BTN", "Bhutan"),
-		newCountry("BO", "BOL", "Bolivia (Plurinational State of)"),
-		newCountry("BA", "BIH", "Bosnia and Herzegovina"), newCountry("BW", "BWA", "Botswana"),
-		newCountry("BR", "BRA", "Brazil"), newCountry("BN", "BRN", "Brunei Darussalam"),
-		newCountry("BG", "BGR", "Bulgaria"), newCountry("BF", "BFA", "Burkina Faso"),
-		newCountry("BI", "BDI", "Burundi"), newCountry("CV", "CPV", "Cabo Verde"),
-		newCountry("KH", "KHM", "Cambodia"), newCountry("CM", "CMR", "Cameroon"),
-		newCountry("CA", "CAN", "Canada"), newCountry("CF", "CAF", "Central African Republic"),
-		newCountry("TD", "TCD", "Chad"), newCountry("CL", "CHL", "Chile"),
-		newCountry("CN", "CHN", "China"), newCountry("CO", "COL", "Colombia"),
-		newCountry("KM", "COM", "Comoros"), newCountry("CG", "COG", "Congo"),
-		newCountry("CD", "COD", "Congo, Democratic Republic of the"),
-		newCountry("CR", "CRI", "Costa Rica"), newCountry("CI", "CIV", "Côte d'Ivoire"),
-		newCountry("HR", "HRV", "Croatia"), newCountry("CU", "CUB", "Cuba"),
-		newCountry("CY", "CYP", "Cyprus"), newCountry("CZ", "CZE", "Czechia"),
-		newCountry("DK", "DNK", "Denmark"), newCountry("DJ", "DJI", "Djibouti"),
-		newCountry("DM", "DMA", "Dominica"), newCountry("DO", "DOM", "Dominican Republic"),
-		newCountry("EC", "ECU", "Ecuador"), newCountry("EG", "EGY", "Egypt"),
-		newCountry("SV", "SLV", "El Salvador"), newCountry("GQ", "GNQ", "Equatorial Guinea"),
-		newCountry("ER", "ERI", "Eritrea"), newCountry("EE", "EST", "Estonia"),
-		newCountry("SZ", "SWZ", "Eswatini"), newCountry("ET", "ETH", "Ethiopia"),
-		newCountry("FI", "FIN", "Finland"), newCountry("FR", "FRA", "France"),
-		newCountry("GA", "GAB", "Gabon"), newCountry("GM", "GMB", "Gambia"),
-		newCountry("GE", "GEO", "Georgia"), newCountry("DE", "DEU", "Germany"),
-		newCountry("GH", "GHA", "Ghana"), newCountry("GR", "GRC", "Greece"),
-		newCountry("GD", "GRD", "Grenada"), newCountry("GT", "GTM", "Guatemala"),
-		newCountry("GN", "GIN", "Guinea"), newCountry("GW", "GNB", "Guinea-Bissau"),
-		newCountry("GY", "GUY", "Guyana"), newCountry("HT", "HTI", "Haiti"),
-		newCountry("HN", "HND", "Honduras"), newCountry("HU", "HUN", "Hungary"),
-		newCountry("IS", "ISL", "Iceland"), newCountry("IN", "IND", "India"),
-		newCountry("ID", "IDN", "Indonesia"), newCountry("IR", "IRN", "Iran (Islamic Republic of)"),
-		newCountry("IQ", "IRQ", "Iraq"), newCountry("IE", "IRL", "Ireland"),
-		newCountry("IL", "ISR", "Israel"), newCountry("IT", "ITA", "Italy"),
-		newCountry("JM", "JAM", "Jamaica"), newCountry("JP", "JPN", "Japan"),
-		newCountry("JO", "JOR", "Jordan"), newCountry("KZ", "KAZ", "Kazakhstan"),
-		newCountry("KE", "KEN", "Kenya"), newCountry("KW", "KWT", "Kuwait"),
-		newCountry("KG", "KGZ", "Kyrgyzstan"), newCountry("LA", "LAO", "Lao People's Democratic Republic"),
-		newCountry("LV", "LVA", "Latvia"), newCountry("LB", "LBN", "Lebanon"),
-		newCountry("LS", "LSO", "Lesotho"), newCountry("LR", "LBR", "Liberia"),
-		newCountry("LY", "LBY", "Libya"), newCountry("LI", "LIE", "Liechtenstein"),
-		newCountry("LT", "LTU", "Lithuania"), newCountry("LU", "LUX", "Luxembourg"),
-		newCountry("MG", "MDG", "Madagascar"), newCountry("MW", "MWI", "Malawi"),
-		newCountry("MY", "MYS", "Malaysia"), newCountry("MV", "MDV", "Maldives"),
-		newCountry("ML", "MLI", "Mali"), newCountry("MT", "MLT", "Malta"),
-		newCountry("MR", "MRT", "Mauritania"), newCountry("MU", "MUS", "Mauritius"),
-		newCountry("MX", "MEX", "Mexico"), newCountry("MD", "MDA", "Moldova, Republic of"),
-		newCountry("MC", "MCO", "Monaco"), newCountry("MN", "MNG", "Mongolia"),
-		newCountry("ME", "MNE", "Montenegro"), newCountry("MA", "MAR", "Morocco"),
-		newCountry("MZ", "MOZ", "Mozambique"), newCountry("MM", "MMR", "Myanmar"),
-		newCountry("NA", "NAM", "Namibia"), newCountry("NP", "NPL", "Nepal"),
-		newCountry("NL", "NLD", "Netherlands"), newCountry("NZ", "NZL", "New Zealand"),
-		newCountry("NI", "NIC", "Nicaragua"), newCountry("NE", "NER", "Niger"),
-		newCountry("NG", "NGA", "Nigeria"), newCountry("KP", "PRK", "North Korea"),
-		newCountry("MK", "MKD", "North Macedonia"), newCountry("NO", "NOR", "Norway"),
-		newCountry("OM", "OMN", "Oman"), newCountry("PK", "PAK", "Pakistan"),
-		newCountry("PS", "PSE", "Palestine, State of"), newCountry("PA", "PAN", "Panama"),
-		newCountry("PY", "PRY", "Paraguay"), newCountry("PE", "PER", "Peru"),
-		newCountry("PH", "PHL", "Philippines"), newCountry("PL", "POL", "Poland"),
-		newCountry("PT", "PRT", "Portugal"), newCountry("PR", "PRI", "Puerto Rico"),
-		newCountry("QA", "QAT", "Qatar"), newCountry("RO", "ROU", "Romania"),
-		newCountry("RU", "RUS", "Russian Federation"), newCountry("RW", "RWA", "Rwanda"),
-		newCountry("KN", "KNA", "Saint Kitts and Nevis"), newCountry("LC", "LCA", "Saint Lucia"),
-		newCountry("VC", "VCT", "Saint Vincent and the Grenadines"),
-		newCountry("SM", "SMR", "San Marino"), newCountry("ST", "STP", "Sao Tome and Principe"),
-		newCountry("SA", "SAU", "Saudi Arabia"),
-		newCountry("SN", "SEN", "Senegal"), newCountry("RS", "SRB", "Serbia"),
-		newCountry("SC", "SYC", "Seychelles"), newCountry("SL", "SLE", "Sierra Leone"),
-		newCountry("SG", "SGP", "Singapore"), newCountry("SK", "SVK", "Slovakia"),
-		newCountry("SI", "SVN", "Slovenia"), newCountry("SO", "SOM", "Somalia"),
-		newCountry("ZA", "ZAF", "South Africa"), newCountry("KR", "KOR", "South Korea"),
-		newCountry("SS", "SSD", "South Sudan"), newCountry("ES", "ESP", "Spain"),
-		newCountry("LK", "LKA", "Sri Lanka"), newCountry("SD", "SDN", "Sudan"),
-		newCountry("SE", "SWE", "Sweden"), newCountry("CH", "CHE", "Switzerland"),
-		newCountry("SY", "SYR", "Syrian Arab Republic"), newCountry("TW", "TWN", "Taiwan, Province of China"),
-		newCountry("TJ", "TJK", "Tajikistan"),
-		newCountry("TZ", "TZA", "Tanzania, United Republic of"), newCountry("TH", "THA", "Thailand"),
-		newCountry("TG", "TGO", "Togo"),
-		newCountry("TT", "TTO", "Trinidad and Tobago"), newCountry("TN", "TUN", "Tunisia"),
-		newCountry("TR", "TUR", "Turkey"), newCountry("TM", "TKM", "Turkmenistan"),
-		newCountry("UG", "UGA", "Uganda"), newCountry("UA", "UKR", "Ukraine"),
-		newCountry("AE", "ARE", "United Arab Emirates"),
-		newCountry("GB", "GBR", "United Kingdom of Great Britain and Northern Ireland"),
-		newCountry("US", "USA", "United States of America"),
-		newCountry("UY", "URY", "Uruguay"), newCountry("UZ", "UZB", "Uzbekistan"),
-		newCountry("VE", "VEN", "Venezuela (Bolivarian Republic of)"),
-		newCountry("VN", "VNM", "Viet Nam"), newCountry("EH", "ESH", "Western Sahara"),
-		newCountry("YE", "YEM", "Yemen"), newCountry("ZM", "ZMB", "Zambia"),
-		newCountry("ZW", "ZWE", "Zimbabwe"),
+		newCountry("AF", "AFG", "004", "Afghanistan"), newCountry("AL", "ALB", "008", "Albania"),
+		newCountry("DZ", "DZA", "012", "Algeria"), newCountry("AD", "AND", "020", "Andorra"),
+		newCountry("AO", "AGO", "024", "Angola"), newCountry("AG", "ATG", "028", "Antigua and Barbuda"),
+		newCountry("AR", "ARG", "032", "Argentina"), newCountry("AM", "ARM", "051", "Armenia"),
+		newCountry("AU", "AUS", "036", "Australia"), newCountry("AT", "AUT", "040", "Austria"),
+		newCountry("AZ", "AZE", "031", "Azerbaijan"), newCountry("BS", "BHS", "044", "Bahamas"),
+		newCountry("BH", "BHR", "048", "Bahrain"), newCountry("BD", "BGD", "050", "Bangladesh"),
+		newCountry("BB", "BRB", "052", "Barbados"), newCountry("BY", "BLR", "112", "Belarus"),
+		newCountry("BE", "BEL", "056", "Belgium"), newCountry("BZ", "BLZ", "084", "Belize"),
+		newCountry("BJ", "BEN", "204", "Benin"), newCountry("BT", "BTN", "064", "Bhutan"),
+		newCountry("BO", "BOL", "068", "Bolivia (Plurinational State of)"),
+		newCountry("BA", "BIH", "070", "Bosnia and Herzegovina"), newCountry("BW", "BWA", "072", "Botswana"),
+		newCountry("BR", "BRA", "076", "Brazil"), newCountry("BN", "BRN", "096", "Brunei Darussalam"),
+		newCountry("BG", "BGR", "100", "Bulgaria"), newCountry("BF", "BFA", "854", "Burkina Faso"),
+		newCountry("BI", "BDI", "108", "Burundi"), newCountry("CV", "CPV", "132", "Cabo Verde"),
+		newCountry("KH", "KHM", "116", "Cambodia"), newCountry("CM", "CMR", "120", "Cameroon"),
+		newCountry("CA", "CAN", "124", "Canada"), newCountry("CF", "CAF", "140", "Central African Republic"),
+		newCountry("TD", "TCD", "148", "Chad"), newCountry("CL", "CHL", "152", "Chile"),
+		newCountry("CN", "CHN", "156", "China"), newCountry("CO", "COL", "170", "Colombia"),
+		newCountry("KM", "COM", "174", "Comoros"), newCountry("CG", "COG", "178", "Congo"),
+		newCountry("CD", "COD", "180", "Congo, Democratic Republic of the"),
+		newCountry("CR", "CRI", "188", "Costa Rica"), newCountry("CI", "CIV", "384", "Côte d'Ivoire"),
+		newCountry("HR", "HRV", "191", "Croatia"), newCountry("CU", "CUB", "192", "Cuba"),
+		newCountry("CY", "CYP", "196", "Cyprus"), newCountry("CZ", "CZE", "203", "Czechia"),
+		newCountry("DK", "DNK", "208", "Denmark"), newCountry("DJ", "DJI", "262", "Djibouti"),
+		newCountry("DM", "DMA", "212", "Dominica"), newCountry("DO", "DOM", "214", "Dominican Republic"),
+		newCountry("EC", "ECU", "218", "Ecuador"), newCountry("EG", "EGY", "818", "Egypt"),
+		newCountry("SV", "SLV", "222", "El Salvador"), newCountry("GQ", "GNQ", "226", "Equatorial Guinea"),
+		newCountry("ER", "ERI", "232", "Eritrea"), newCountry("EE", "EST", "233", "Estonia"),
+		newCountry("SZ", "SWZ", "748", "Eswatini"), newCountry("ET", "ETH", "231", "Ethiopia"),
+		newCountry("FI", "FIN", "246", "Finland"), newCountry("FR", "FRA", "250", "France"),
+		newCountry("GA", "GAB", "266", "Gabon"), newCountry("GM", "GMB", "270", "Gambia"),
+		newCountry("GE", "GEO", "268", "Georgia"), newCountry("DE", "DEU", "276", "Germany"),
+		newCountry("GH", "GHA", "288", "Ghana"), newCountry("GR", "GRC", "300", "Greece"),
+		newCountry("GD", "GRD", "308", "Grenada"), newCountry("GT", "GTM", "320", "Guatemala"),
+		newCountry("GN", "GIN", "324", "Guinea"), newCountry("GW", "GNB", "624", "Guinea-Bissau"),
+		newCountry("GY", "GUY", "328", "Guyana"), newCountry("HT", "HTI", "332", "Haiti"),
+		newCountry("HN", "HND", "340", "Honduras"), newCountry("HU", "HUN", "348", "Hungary"),
+		newCountry("IS", "ISL", "352", "Iceland"), newCountry("IN", "IND", "356", "India"),
+		newCountry("ID", "IDN", "360", "Indonesia"), newCountry("IR", "IRN", "364", "Iran (Islamic Republic of)"),
+		newCountry("IQ", "IRQ", "368", "Iraq"), newCountry("IE", "IRL", "372", "Ireland"),
+		newCountry("IL", "ISR", "376", "Israel"), newCountry("IT", "ITA", "380", "Italy"),
+		newCountry("JM", "JAM", "388", "Jamaica"), newCountry("JP", "JPN", "392", "Japan"),
+		newCountry("JO", "JOR", "400", "Jordan"), newCountry("KZ", "KAZ", "398", "Kazakhstan"),
+		newCountry("KE", "KEN", "404", "Kenya"), newCountry("KW", "KWT", "414", "Kuwait"),
+		newCountry("KG", "KGZ", "417", "Kyrgyzstan"), newCountry("LA", "LAO", "418", "Lao People's Democratic Republic"),
+		newCountry("LV", "LVA", "428", "Latvia"), newCountry("LB", "LBN", "422", "Lebanon"),
+		newCountry("LS", "LSO", "426", "Lesotho"), newCountry("LR", "LBR", "430", "Liberia"),
+		newCountry("LY", "LBY", "434", "Libya"), newCountry("LI", "LIE", "438", "Liechtenstein"),
+		newCountry("LT", "LTU", "440", "Lithuania"), newCountry("LU", "LUX", "442", "Luxembourg"),
+		newCountry("MG", "MDG", "450", "Madagascar"), newCountry("MW", "MWI", "454", "Malawi"),
+		newCountry("MY", "MYS", "458", "Malaysia"), newCountry("MV", "MDV", "462", "Maldives"),
+		newCountry("ML", "MLI", "466", "Mali"), newCountry("MT", "MLT", "470", "Malta"),
+		newCountry("MR", "MRT", "478", "Mauritania"), newCountry("MU", "MUS", "480", "Mauritius"),
+		newCountry("MX", "MEX", "484", "Mexico"), newCountry("MD", "MDA", "498", "Moldova, Republic of"),
+		newCountry("MC", "MCO", "492", "Monaco"), newCountry("MN", "MNG", "496", "Mongolia"),
+		newCountry("ME", "MNE", "499", "Montenegro"), newCountry("MA", "MAR", "504", "Morocco"),
+		newCountry("MZ", "MOZ", "508", "Mozambique"), newCountry("MM", "MMR", "104", "Myanmar"),
+		newCountry("NA", "NAM", "516", "Namibia"), newCountry("NP", "NPL", "524", "Nepal"),
+		newCountry("NL", "NLD", "528", "Netherlands"), newCountry("NZ", "NZL", "554", "New Zealand"),
+		newCountry("NI", "NIC", "558", "Nicaragua"), newCountry("NE", "NER", "562", "Niger"),
+		newCountry("NG", "NGA", "566", "Nigeria"), newCountry("KP", "PRK", "408", "North Korea"),
+		newCountry("MK", "MKD", "807", "North Macedonia"), newCountry("NO", "NOR", "578", "Norway"),
+		newCountry("OM", "OMN", "512", "Oman"), newCountry("PK", "PAK", "586", "Pakistan"),
+		newCountry("PS", "PSE", "275", "Palestine, State of"), newCountry("PA", "PAN", "591", "Panama"),
+		newCountry("PY", "PRY", "600", "Paraguay"), newCountry("PE", "PER", "604", "Peru"),
+		newCountry("PH", "PHL", "608", "Philippines"), newCountry("PL", "POL", "616", "Poland"),
+		newCountry("PT", "PRT", "620", "Portugal"), newCountry("PR", "PRI", "630", "Puerto Rico"),
+		newCountry("QA", "QAT", "634", "Qatar"), newCountry("RO", "ROU", "642", "Romania"),
+		newCountry("RU", "RUS", "643", "Russian Federation"), newCountry("RW", "RWA", "646", "Rwanda"),
+		newCountry("KN", "KNA", "659", "Saint Kitts and Nevis"), newCountry("LC", "LCA", "662", "Saint Lucia"),
+		newCountry("VC", "VCT", "670", "Saint Vincent and the Grenadines"),
+		newCountry("SM", "SMR", "674", "San Marino"), newCountry("ST", "STP", "678", "Sao Tome and Principe"),
+		newCountry("SA", "SAU", "682", "Saudi Arabia"),
+		newCountry("SN", "SEN", "686", "Senegal"), newCountry("RS", "SRB", "688", "Serbia"),
+		newCountry("SC", "SYC", "690", "Seychelles"), newCountry("SL", "SLE", "694", "Sierra Leone"),
+		newCountry("SG", "SGP", "702", "Singapore"), newCountry("SK", "SVK", "703", "Slovakia"),
+		newCountry("SI", "SVN", "705", "Slovenia"), newCountry("SO", "SOM", "706", "Somalia"),
+		newCountry("ZA", "ZAF", "710", "South Africa"), newCountry("KR", "KOR", "410", "South Korea"),
+		newCountry("SS", "SSD", "728", "South Sudan"), newCountry("ES", "ESP", "724", "Spain"),
+		newCountry("LK", "LKA", "144", "Sri Lanka"), newCountry("SD", "SDN", "729", "Sudan"),
+		newCountry("SE", "SWE", "752", "Sweden"), newCountry("CH", "CHE", "756", "Switzerland"),
+		newCountry("SY", "SYR", "760", "Syrian Arab Republic"), newCountry("TW", "TWN", "158", "Taiwan, Province of China"),
+		newCountry("TJ", "TJK", "762", "Tajikistan"),
+		newCountry("TZ", "TZA", "834", "Tanzania, United Republic of"), newCountry("TH", "THA", "764", "Thailand"),
+		newCountry("TG", "TGO", "768", "Togo"),
+		newCountry("TT", "TTO", "780", "Trinidad and Tobago"), newCountry("TN", "TUN", "788", "Tunisia"),
+		newCountry("TR", "TUR", "792", "Turkey"), newCountry("TM", "TKM", "795", "Turkmenistan"),
+		newCountry("UG", "UGA", "800", "Uganda"), newCountry("UA", "UKR", "804", "Ukraine"),
+		newCountry("AE", "ARE", "784", "United Arab Emirates"),
+		newCountry("GB", "GBR", "826", "United Kingdom of Great Britain and Northern Ireland"),
+		newCountry("US", "USA", "840", "United States of America"),
+		newCountry("UY", "URY", "858", "Uruguay"), newCountry("UZ", "UZB", "860", "Uzbekistan"),
+		newCountry("VE", "VEN", "862", "Venezuela (Bolivarian Republic of)"),
+		newCountry("VN", "VNM", "704", "Viet Nam"), newCountry("EH", "ESH", "732", "Western Sahara"),
+		newCountry("YE", "YEM", "887", "Yemen"), newCountry("ZM", "ZMB", "894", "Zambia"),
+		newCountry("ZW", "ZWE", "716", "Zimbabwe"),
 	}
 }
 
@@ -158,12 +159,11 @@ func getAliasData() map[string][]string {
 		},
 		"KR": {
 			"south korea", "republic of korea", "korea south", "corée du sud",
-			"südkorea", "soth korea", "south koria", "hanguk", "rok", "sk", "korea",
+			"südkorea", "soth korea", "south koria", "hanguk", "rok", "korea",
 		},
 		"RU": {
 			"russia", "russie", "russland", "russian federation", "rossiya",
-			"rossia", "rusia", "rusija", "russa", "russha", "soviet union",
-			"ussr", "union of soviet socialist republics", "sovjet",
+			"rossia", "rusia", "rusija", "russa", "russha",
 		},
 		"DE": {
 			"germany", "deutschland", "allemagne", "germania", "alemania",
@@ -220,7 +220,7 @@ func getAliasData() map[string][]string {
 		},
 		"CZ": {
 			"czech republic", "česká republika", "république tchèque",
-			"tschechische republik", "czechia", "czechoslovakia", "checz",
+			"tschechische republik", "czechia", "checz",
 			"chekia", "česko",
 		},
 		"SK": {
@@ -243,13 +243,13 @@ func getAliasData() map[string][]string {
 			"the phillipines", "pilipinas", "pinoyland",
 		},
 		"IR": {
-			"iran", "persia", "iraan", "irun", "irá", "irák",
+			"iran", "persia", "iraan", "irun",
 		},
 		"IQ": {
 			"iraq", "irak", "irac", "irack",
 		},
 		"ZA": {
-			"south africa", "south afrika", "soth africa", "azania", "sa", "za",
+			"south africa", "south afrika", "soth africa", "azania", "za",
 		},
 		"AU": {
 			"australia", "aussie", "oz", "straya", "down under", "austraila", "austalia",
@@ -0,0 +1,153 @@
+package data
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"country-iso-matcher/src/internal/domain"
+)
+
+// Column roles of a postal codes table
+const (
+	RolePostalCountry = "code"
+	RolePostalPattern = "pattern"
+	RolePostalExample = "example"
+)
+
+// embeddedPostalCodes are the postal code formats compiled into the binary,
+// generated from data/postal_codes.csv
+//
+//go:embed embedded/postal_codes.json
+var embeddedPostalCodes []byte
+
+// parseEmbeddedPostalCodes decodes and compiles the embedded postal code formats
+func parseEmbeddedPostalCodes() ([]domain.PostalCodeFormat, error) {
+	var formats []domain.PostalCodeFormat
+	if err := json.Unmarshal(embeddedPostalCodes, &formats); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded postal code data: %w", err)
+	}
+	for i := range formats {
+		if err := formats[i].Compile(); err != nil {
+			return nil, fmt.Errorf("embedded postal code data: %w", err)
+		}
+	}
+	return formats, nil
+}
+
+// ReadPostalCodeTable reads a postal codes table whose header names each column:
+// code (the ISO2 code of the country), pattern and the optional example.
+// Example:
+//
+//	code,pattern,example
+//	DE,\d{5},10115
+//	GB,[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2},SW1A 1AA
+//
+// A pattern is a regular expression that must match a whole postal code written in
+// capitals. A country may have several rows, one per format. Every invalid row fails
+// the load with its row.
+func ReadPostalCodeTable(path string, comma rune) ([]domain.PostalCodeFormat, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open postal codes file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comma = comma
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	name := filepath.Base(path)
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("postal codes file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	columns, err := postalLayout(header)
+	if err != nil {
+		return nil, fmt.Errorf("%s header: %w", name, err)
+	}
+
+	var formats []domain.PostalCodeFormat
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+
+		field := func(role string) string {
+			if i, exists := columns[role]; exists && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		line, _ := reader.FieldPos(0)
+		format := domain.PostalCodeFormat{
+			Country: strings.ToUpper(field(RolePostalCountry)),
+			Pattern: field(RolePostalPattern),
+			Example: field(RolePostalExample),
+		}
+		if format.Country == "" && format.Pattern == "" {
+			continue // Blank row
+		}
+		if len(format.Country) != 2 {
+			return nil, fmt.Errorf("%s row %d: %q is not an ISO2 code", name, line, format.Country)
+		}
+		if format.Pattern == "" {
+			return nil, fmt.Errorf("%s row %d: %s has no pattern", name, line, format.Country)
+		}
+		if err := format.Compile(); err != nil {
+			return nil, fmt.Errorf("%s row %d: %w", name, line, err)
+		}
+
+		formats = append(formats, format)
+	}
+
+	if len(formats) == 0 {
+		return nil, fmt.Errorf("no valid postal code formats found in file")
+	}
+
+	return formats, nil
+}
+
+// postalLayout returns the column index of every role of a postal codes header
+func postalLayout(header []string) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		role := strings.ToLower(strings.TrimSpace(column))
+		switch role {
+		case RolePostalCountry, RolePostalPattern, RolePostalExample:
+		default:
+			return nil, fmt.Errorf("column %d (%q) is not a known column; use code, pattern or example", i+1, column)
+		}
+
+		if previous, exists := columns[role]; exists {
+			return nil, fmt.Errorf("columns %d and %d are both %s", previous+1, i+1, role)
+		}
+		columns[role] = i
+	}
+
+	if _, exists := columns[RolePostalCountry]; !exists {
+		return nil, fmt.Errorf("no code column")
+	}
+	if _, exists := columns[RolePostalPattern]; !exists {
+		return nil, fmt.Errorf("no pattern column")
+	}
+
+	return columns, nil
+}
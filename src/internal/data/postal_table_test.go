@@ -0,0 +1,97 @@
+package data_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"country-iso-matcher/src/internal/data"
+)
+
+func TestWithPostalCodes(t *testing.T) {
+	loader, ok := data.WithPostalCodes(data.WithSubdivisions(data.NewMemoryLoader(), ""), "").(data.PostalCodeLoader)
+	if !ok {
+		t.Fatal("expected the loader to provide postal code formats")
+	}
+	if _, ok := loader.(data.SubdivisionLoader); !ok {
+		t.Error("expected the loader to keep providing subdivisions")
+	}
+
+	formats, err := loader.LoadPostalCodes()
+	if err != nil {
+		t.Fatalf("failed to load embedded postal codes: %v", err)
+	}
+	examples := map[string]string{"RO": "010011", "DE": "10115", "GB": "SW1A 1AA", "CA": "K1A 0B1", "NL": "1012 JS"}
+	for _, format := range formats {
+		if example, exists := examples[format.Country]; exists && format.Matches(example) {
+			delete(examples, format.Country)
+		}
+	}
+	for country, example := range examples {
+		t.Errorf("no embedded format of %s matches %q", country, example)
+	}
+
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		valid   bool
+	}{
+		{
+			name:    "valid",
+			file:    "valid.csv",
+			content: "code,pattern,example\nro,\\d{6},010011\n",
+			valid:   true,
+		},
+		{
+			name:    "tab separated",
+			file:    "valid.tsv",
+			content: "code\tpattern\nRO\t\\d{6}\n",
+			valid:   true,
+		},
+		{
+			name:    "malformed code",
+			file:    "code.csv",
+			content: "code,pattern\nROU,\\d{6}\n",
+		},
+		{
+			name:    "invalid pattern",
+			file:    "pattern.csv",
+			content: "code,pattern\nRO,(\\d{6}\n",
+		},
+		{
+			name:    "example mismatch",
+			file:    "example.csv",
+			content: "code,pattern,example\nRO,\\d{6},01001\n",
+		},
+		{
+			name:    "missing pattern column",
+			file:    "columns.csv",
+			content: "code,example\nRO,010011\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(dir, tt.file)
+			if err := os.WriteFile(file, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			formats, err := data.WithPostalCodes(data.NewMemoryLoader(), file).(data.PostalCodeLoader).LoadPostalCodes()
+			if !tt.valid {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(formats) != 1 || formats[0].Country != "RO" || !formats[0].Matches("010011") || formats[0].Matches("01001") {
+				t.Errorf("unexpected formats: %+v", formats)
+			}
+		})
+	}
+}
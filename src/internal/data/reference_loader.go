@@ -0,0 +1,111 @@
+package data
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"country-iso-matcher/src/internal/domain"
+)
+
+// HistoricalLoader is implemented by loaders that also provide countries withdrawn from
+// ISO 3166-1 (ISO 3166-3), such as the USSR or Yugoslavia
+type HistoricalLoader interface {
+	LoadHistorical() ([]domain.HistoricalCountry, error)
+}
+
+// SubdivisionLoader is implemented by loaders that also provide the subdivisions of
+// countries (ISO 3166-2), such as US states or German Länder
+type SubdivisionLoader interface {
+	LoadSubdivisions() ([]domain.Subdivision, error)
+}
+
+// PostalCodeLoader is implemented by loaders that also provide the postal code formats
+// of countries, such as \d{5} for Germany
+type PostalCodeLoader interface {
+	LoadPostalCodes() ([]domain.PostalCodeFormat, error)
+}
+
+// referenceLoader adds the reference data that does not depend on the country source,
+// historical countries, subdivisions and postal code formats, to a loader of current countries.
+// Each comes from its file, or from the data compiled into the binary when the file is empty.
+type referenceLoader struct {
+	Loader
+	historicalFile   string
+	subdivisionsFile string
+	postalCodesFile  string
+}
+
+// WithHistorical adds the historical countries of file to loader. An empty file
+// uses the list compiled into the binary.
+func WithHistorical(loader Loader, file string) Loader {
+	ref := withReference(loader)
+	ref.historicalFile = file
+	return ref
+}
+
+// WithSubdivisions adds the subdivisions of a CSV or TSV file (see ReadSubdivisionTable)
+// to loader. An empty file uses the subdivisions compiled into the binary.
+func WithSubdivisions(loader Loader, file string) Loader {
+	ref := withReference(loader)
+	ref.subdivisionsFile = file
+	return ref
+}
+
+// WithPostalCodes adds the postal code formats of a CSV or TSV file (see ReadPostalCodeTable)
+// to loader. An empty file uses the formats compiled into the binary.
+func WithPostalCodes(loader Loader, file string) Loader {
+	ref := withReference(loader)
+	ref.postalCodesFile = file
+	return ref
+}
+
+// withReference returns a copy of loader's reference data settings, or new defaults
+func withReference(loader Loader) *referenceLoader {
+	if ref, ok := loader.(*referenceLoader); ok {
+		copied := *ref
+		return &copied
+	}
+	return &referenceLoader{Loader: loader}
+}
+
+// LoadHistorical loads the historical countries from the file, or the embedded list
+func (l *referenceLoader) LoadHistorical() ([]domain.HistoricalCountry, error) {
+	if l.historicalFile == "" {
+		return ParseHistorical(embeddedHistorical, "embedded historical data")
+	}
+
+	content, err := os.ReadFile(l.historicalFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read historical countries file %s: %w", l.historicalFile, err)
+	}
+	return ParseHistorical(content, l.historicalFile)
+}
+
+// LoadSubdivisions loads the subdivisions from the file, or the embedded list.
+// Files ending in .tsv are tab separated.
+func (l *referenceLoader) LoadSubdivisions() ([]domain.Subdivision, error) {
+	if l.subdivisionsFile == "" {
+		return parseEmbeddedSubdivisions()
+	}
+
+	comma := ','
+	if strings.HasSuffix(strings.ToLower(l.subdivisionsFile), ".tsv") {
+		comma = '\t'
+	}
+	return ReadSubdivisionTable(l.subdivisionsFile, comma)
+}
+
+// LoadPostalCodes loads the postal code formats from the file, or the embedded list.
+// Files ending in .tsv are tab separated.
+func (l *referenceLoader) LoadPostalCodes() ([]domain.PostalCodeFormat, error) {
+	if l.postalCodesFile == "" {
+		return parseEmbeddedPostalCodes()
+	}
+
+	comma := ','
+	if strings.HasSuffix(strings.ToLower(l.postalCodesFile), ".tsv") {
+		comma = '\t'
+	}
+	return ReadPostalCodeTable(l.postalCodesFile, comma)
+}
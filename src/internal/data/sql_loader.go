@@ -0,0 +1,281 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"  // postgres driver
+	_ "modernc.org/sqlite" // sqlite driver (pure Go, no cgo)
+
+	"country-iso-matcher/src/internal/config"
+	"country-iso-matcher/src/internal/domain"
+)
+
+// sqlQueryTimeout bounds each load query
+const sqlQueryTimeout = 30 * time.Second
+
+// identifierPattern matches the table and column names accepted from SchemaConfig,
+// optionally qualified by a schema. Names are interpolated into queries, so nothing else is allowed.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// SQLLoader loads country data from a database through database/sql.
+// Table and column names come from config.SchemaConfig; sqlite, postgres and mysql
+// share the same queries and differ only in driver and connection string.
+type SQLLoader struct {
+	db             *sql.DB
+	countriesQuery string
+	aliasesQuery   string
+	codeSystems    []string // Systems of the code columns after the fixed columns, in query order
+	metaFields     []string // Metadata fields of the columns after the code columns, in query order
+}
+
+// NewSQLLoader creates a database loader. The connection is opened lazily on the first load.
+func NewSQLLoader(cfg *config.DatabaseConfig) (*SQLLoader, error) {
+	schema := cfg.Schema
+	identifiers := []string{schema.CountriesTable, schema.AliasesTable, schema.CodeColumn,
+		schema.NameColumn, schema.AliasCodeColumn, schema.AliasNameColumn}
+	if schema.ISO3Column != "" {
+		identifiers = append(identifiers, schema.ISO3Column)
+	}
+	if schema.NumericColumn != "" {
+		identifiers = append(identifiers, schema.NumericColumn)
+	}
+
+	// Extra code columns are selected after the fixed ones, in a stable order
+	systems := make([]string, 0, len(schema.CodeColumns))
+	for system, column := range schema.CodeColumns {
+		if !domain.IsCodeSystem(system) {
+			return nil, fmt.Errorf("unknown code system %q in database schema code_columns", system)
+		}
+		systems = append(systems, system)
+		identifiers = append(identifiers, column)
+	}
+	sort.Strings(systems)
+
+	// Metadata columns follow the code columns, in a stable order as well
+	fields := make([]string, 0, len(schema.MetaColumns))
+	for field, column := range schema.MetaColumns {
+		if !domain.IsMetaField(field) {
+			return nil, fmt.Errorf("unknown metadata field %q in database schema meta_columns", field)
+		}
+		fields = append(fields, field)
+		identifiers = append(identifiers, column)
+	}
+	sort.Strings(fields)
+
+	for _, identifier := range identifiers {
+		if !identifierPattern.MatchString(identifier) {
+			return nil, fmt.Errorf("invalid table or column name %q in database schema", identifier)
+		}
+	}
+
+	driver, dsn, err := dataSourceName(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s database: %w", cfg.Type, err)
+	}
+	// Loads are rare and sequential; keep the pool small
+	db.SetMaxOpenConns(2)
+	db.SetConnMaxIdleTime(time.Minute)
+
+	columns := []string{schema.CodeColumn, schema.NameColumn, "NULL", "NULL"}
+	if schema.ISO3Column != "" {
+		columns[2] = schema.ISO3Column
+	}
+	if schema.NumericColumn != "" {
+		columns[3] = schema.NumericColumn
+	}
+	for _, system := range systems {
+		columns = append(columns, schema.CodeColumns[system])
+	}
+	for _, field := range fields {
+		columns = append(columns, schema.MetaColumns[field])
+	}
+
+	return &SQLLoader{
+		db: db,
+		countriesQuery: fmt.Sprintf("SELECT %s FROM %s ORDER BY %s",
+			strings.Join(columns, ", "), schema.CountriesTable, schema.CodeColumn),
+		codeSystems: systems,
+		metaFields:  fields,
+		aliasesQuery: fmt.Sprintf("SELECT %s, %s FROM %s ORDER BY %s",
+			schema.AliasCodeColumn, schema.AliasNameColumn, schema.AliasesTable, schema.AliasCodeColumn),
+	}, nil
+}
+
+// dataSourceName returns the driver name and connection string for the database type
+func dataSourceName(cfg *config.DatabaseConfig) (string, string, error) {
+	address := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+
+	switch cfg.Type {
+	case "sqlite":
+		// Database is the path of the database file
+		return "sqlite", cfg.Database, nil
+
+	case "postgres":
+		dsn := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(cfg.Username, cfg.Password),
+			Host:     address,
+			Path:     "/" + cfg.Database,
+			RawQuery: url.Values{"sslmode": {cfg.SSLMode}}.Encode(),
+		}
+		return "postgres", dsn.String(), nil
+
+	case "mysql":
+		mysqlConfig := mysql.NewConfig()
+		mysqlConfig.User = cfg.Username
+		mysqlConfig.Passwd = cfg.Password
+		mysqlConfig.Net = "tcp"
+		mysqlConfig.Addr = address
+		mysqlConfig.DBName = cfg.Database
+		return "mysql", mysqlConfig.FormatDSN(), nil
+
+	default:
+		return "", "", fmt.Errorf("unsupported database type: %s (must be postgres, mysql, or sqlite)", cfg.Type)
+	}
+}
+
+// LoadCountries loads countries from the countries table.
+// The name column holds the English name; without an ISO3 column countries have no ISO3 code.
+// The numeric column may hold text or integers; leading zeros are restored.
+func (l *SQLLoader) LoadCountries() ([]domain.Country, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), sqlQueryTimeout)
+	defer cancel()
+
+	rows, err := l.db.QueryContext(ctx, l.countriesQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query countries: %w", err)
+	}
+	defer rows.Close()
+
+	var countries []domain.Country
+	for rows.Next() {
+		var code, name, iso3, numeric sql.NullString
+		codes := make([]sql.NullString, len(l.codeSystems))
+		metaValues := make([]sql.NullString, len(l.metaFields))
+		dest := []any{&code, &name, &iso3, &numeric}
+		for i := range codes {
+			dest = append(dest, &codes[i])
+		}
+		for i := range metaValues {
+			dest = append(dest, &metaValues[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("failed to read country row: %w", err)
+		}
+
+		isoCode := strings.ToUpper(strings.TrimSpace(code.String))
+		countryName := strings.TrimSpace(name.String)
+		if isoCode == "" || countryName == "" {
+			continue // Skip empty entries
+		}
+
+		iso3Code := strings.ToUpper(strings.TrimSpace(iso3.String))
+
+		var numericCode string
+		if value := strings.TrimSpace(numeric.String); value != "" {
+			var ok bool
+			if numericCode, ok = domain.NumericCode(value); !ok {
+				return nil, fmt.Errorf("country %s: %q is not a numeric code", isoCode, value)
+			}
+		}
+
+		var systemCodes map[string]string
+		for i, system := range l.codeSystems {
+			value := strings.TrimSpace(codes[i].String)
+			if value == "" {
+				continue
+			}
+			systemCode, ok := domain.NormalizeCode(system, value)
+			if !ok {
+				return nil, fmt.Errorf("country %s: %q is not a valid %s code", isoCode, value, system)
+			}
+			if systemCodes == nil {
+				systemCodes = make(map[string]string)
+			}
+			systemCodes[system] = systemCode
+		}
+
+		var meta *domain.CountryMeta
+		for i, field := range l.metaFields {
+			value := strings.TrimSpace(metaValues[i].String)
+			if value == "" {
+				continue
+			}
+			if meta == nil {
+				meta = &domain.CountryMeta{}
+			}
+			if err := meta.Set(field, value); err != nil {
+				return nil, fmt.Errorf("country %s: %s: %w", isoCode, field, err)
+			}
+		}
+
+		countries = append(countries, domain.Country{
+			ISO2:    isoCode,
+			ISO3:    iso3Code,
+			Numeric: numericCode,
+			Codes:   systemCodes,
+			Meta:    meta,
+			Names: map[string]string{
+				"en": countryName,
+			},
+			Aliases: []string{},
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read countries: %w", err)
+	}
+
+	if len(countries) == 0 {
+		return nil, fmt.Errorf("no valid countries found in table")
+	}
+
+	return countries, nil
+}
+
+// LoadAliases loads aliases from the aliases table, one alias per row
+func (l *SQLLoader) LoadAliases() (map[string][]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), sqlQueryTimeout)
+	defer cancel()
+
+	rows, err := l.db.QueryContext(ctx, l.aliasesQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query aliases: %w", err)
+	}
+	defer rows.Close()
+
+	aliases := make(map[string][]string)
+	for rows.Next() {
+		var code, alias sql.NullString
+		if err := rows.Scan(&code, &alias); err != nil {
+			return nil, fmt.Errorf("failed to read alias row: %w", err)
+		}
+
+		isoCode := strings.ToUpper(strings.TrimSpace(code.String))
+		aliasName := strings.TrimSpace(alias.String)
+		if isoCode == "" || aliasName == "" {
+			continue
+		}
+
+		aliases[isoCode] = append(aliases[isoCode], aliasName)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read aliases: %w", err)
+	}
+
+	return aliases, nil
+}
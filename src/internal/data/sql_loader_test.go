@@ -0,0 +1,120 @@
+package data_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+
+	"country-iso-matcher/src/internal/config"
+	"country-iso-matcher/src/internal/data"
+)
+
+func newTestDatabase(t *testing.T, statements ...string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "countries.db")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("failed to run %q: %v", statement, err)
+		}
+	}
+	return path
+}
+
+func TestSQLLoader_CustomSchema(t *testing.T) {
+	path := newTestDatabase(t,
+		`CREATE TABLE master_country (iso_a2 TEXT, label TEXT, iso_a3 TEXT, iso_n3 INTEGER, capital_city TEXT)`,
+		`INSERT INTO master_country VALUES ('de', 'Germany', 'DEU', 276, 'Berlin'), ('FR', 'France', 'FRA', NULL, NULL), ('', 'Nowhere', NULL, 4, NULL)`,
+		`CREATE TABLE master_alias (country TEXT, text TEXT)`,
+		`INSERT INTO master_alias VALUES ('DE', 'Deutschland'), ('de', 'Allemagne'), ('FR', ' ')`,
+	)
+
+	cfg := config.DefaultConfig().Database
+	cfg.Enabled = true
+	cfg.Type = "sqlite"
+	cfg.Database = path
+	cfg.Schema = config.SchemaConfig{
+		CountriesTable:  "master_country",
+		AliasesTable:    "master_alias",
+		CodeColumn:      "iso_a2",
+		NameColumn:      "label",
+		ISO3Column:      "iso_a3",
+		NumericColumn:   "iso_n3",
+		AliasCodeColumn: "country",
+		AliasNameColumn: "text",
+		MetaColumns:     map[string]string{"capital": "capital_city"},
+	}
+
+	loader, err := data.NewSQLLoader(&cfg)
+	if err != nil {
+		t.Fatalf("failed to create loader: %v", err)
+	}
+
+	countries, err := loader.LoadCountries()
+	if err != nil {
+		t.Fatalf("failed to load countries: %v", err)
+	}
+	if len(countries) != 2 {
+		t.Fatalf("expected 2 countries, got %d", len(countries))
+	}
+	for _, country := range countries {
+		if country.ISO2 == "DE" && (country.ISO3 != "DEU" || country.Numeric != "276" || country.Names["en"] != "Germany" || country.Meta.Capital != "Berlin") {
+			t.Errorf("unexpected country: %+v", country)
+		}
+		if country.ISO2 == "FR" && country.Meta != nil {
+			t.Errorf("expected no metadata for FR, got %+v", country.Meta)
+		}
+	}
+
+	aliases, err := loader.LoadAliases()
+	if err != nil {
+		t.Fatalf("failed to load aliases: %v", err)
+	}
+	if len(aliases["DE"]) != 2 || len(aliases["FR"]) != 0 {
+		t.Errorf("unexpected aliases: %v", aliases)
+	}
+}
+
+func TestSQLLoader_InvalidIdentifier(t *testing.T) {
+	cfg := config.DefaultConfig().Database
+	cfg.Type = "sqlite"
+	cfg.Database = filepath.Join(t.TempDir(), "countries.db")
+	cfg.Schema.CountriesTable = "countries; DROP TABLE countries"
+
+	if _, err := data.NewSQLLoader(&cfg); err == nil {
+		t.Fatal("expected invalid table name to be rejected")
+	}
+}
+
+func TestSQLLoader_DefaultSchemaWithoutISO3(t *testing.T) {
+	path := newTestDatabase(t,
+		`CREATE TABLE countries (code TEXT, name TEXT)`,
+		`INSERT INTO countries VALUES ('RO', 'Romania')`,
+	)
+
+	cfg := config.DefaultConfig().Database
+	cfg.Enabled = true
+	cfg.Type = "sqlite"
+	cfg.Database = path
+
+	loader, err := data.NewSQLLoader(&cfg)
+	if err != nil {
+		t.Fatalf("failed to create loader: %v", err)
+	}
+
+	countries, err := loader.LoadCountries()
+	if err != nil {
+		t.Fatalf("failed to load countries: %v", err)
+	}
+	if len(countries) != 1 || countries[0].ISO2 != "RO" || countries[0].ISO3 != "" {
+		t.Errorf("expected Romania without ISO3, got %+v", countries)
+	}
+}
@@ -0,0 +1,204 @@
+package data
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"country-iso-matcher/src/internal/domain"
+)
+
+// Column roles of a subdivisions table besides name_<lang> and aliases
+const (
+	RoleSubdivisionCode = "code"
+	RoleSubdivisionType = "type"
+)
+
+// embeddedSubdivisions are the ISO 3166-2 subdivisions compiled into the binary,
+// generated from data/subdivisions.csv
+//
+//go:embed embedded/subdivisions.json
+var embeddedSubdivisions []byte
+
+// parseEmbeddedSubdivisions decodes the embedded subdivisions
+func parseEmbeddedSubdivisions() ([]domain.Subdivision, error) {
+	var subdivisions []domain.Subdivision
+	if err := json.Unmarshal(embeddedSubdivisions, &subdivisions); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded subdivision data: %w", err)
+	}
+	return subdivisions, nil
+}
+
+// ReadSubdivisionTable reads a subdivisions table whose header names each column:
+// code (the ISO 3166-2 code), type, name_<lang> and aliases.
+// Example:
+//
+//	code,type,name_en,name_de,aliases
+//	DE-BY,state,Bavaria,Bayern,Freistaat Bayern
+//
+// The country of each subdivision is the part of its code before the hyphen.
+// Every invalid row fails the load with its row and column.
+func ReadSubdivisionTable(path string, comma rune) ([]domain.Subdivision, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open subdivisions file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comma = comma
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	name := filepath.Base(path)
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("subdivisions file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	roles, err := subdivisionLayout(header)
+	if err != nil {
+		return nil, fmt.Errorf("%s header: %w", name, err)
+	}
+
+	var subdivisions []domain.Subdivision
+	rowOf := make(map[string]int) // Code -> line, to report duplicates
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		subdivision, err := parseSubdivisionRow(record, roles, func(column int) string {
+			return fmt.Sprintf("%s row %d, column %d", name, line, column+1)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if subdivision == nil {
+			continue
+		}
+
+		if previous, exists := rowOf[subdivision.Code]; exists {
+			return nil, fmt.Errorf("%s row %d: duplicate subdivision %s (first defined in row %d)", name, line, subdivision.Code, previous)
+		}
+		rowOf[subdivision.Code] = line
+
+		subdivisions = append(subdivisions, *subdivision)
+	}
+
+	if len(subdivisions) == 0 {
+		return nil, fmt.Errorf("no valid subdivisions found in file")
+	}
+
+	return subdivisions, nil
+}
+
+// subdivisionLayout returns the role of every column of a subdivisions header
+func subdivisionLayout(header []string) ([]string, error) {
+	roles := make([]string, len(header))
+	seen := make(map[string]int, len(header))
+	hasName := false
+
+	for i, column := range header {
+		role := strings.ToLower(strings.TrimSpace(column))
+		switch {
+		case role == RoleSubdivisionCode, role == RoleSubdivisionType, role == RoleAliases:
+		case strings.HasPrefix(role, RoleNamePrefix):
+			var err error
+			if role, err = validateRole(column); err != nil {
+				return nil, fmt.Errorf("column %d (%q): %w", i+1, column, err)
+			}
+			hasName = true
+		default:
+			return nil, fmt.Errorf("column %d (%q) is not a known column; use code, type, name_<lang> or aliases", i+1, column)
+		}
+
+		if previous, exists := seen[role]; exists {
+			return nil, fmt.Errorf("columns %d and %d are both %s", previous, i+1, role)
+		}
+		seen[role] = i + 1
+		roles[i] = role
+	}
+
+	if _, exists := seen[RoleSubdivisionCode]; !exists {
+		return nil, fmt.Errorf("no code column")
+	}
+	if !hasName {
+		return nil, fmt.Errorf("no name column (name_<lang>)")
+	}
+
+	return roles, nil
+}
+
+// parseSubdivisionRow converts one row into a subdivision. Blank rows yield nil.
+func parseSubdivisionRow(record []string, roles []string, where func(column int) string) (*domain.Subdivision, error) {
+	blank := true
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			blank = false
+			break
+		}
+	}
+	if blank {
+		return nil, nil
+	}
+
+	if len(record) > len(roles) {
+		return nil, fmt.Errorf("%s: unexpected extra column (expected %d columns)", where(len(roles)), len(roles))
+	}
+
+	subdivision := &domain.Subdivision{Names: make(map[string]string)}
+	for i, role := range roles {
+		var value string
+		if i < len(record) {
+			value = strings.TrimSpace(record[i])
+		}
+
+		switch role {
+		case RoleSubdivisionCode:
+			country, local, ok := domain.SplitSubdivisionCode(value)
+			if !ok {
+				return nil, fmt.Errorf("%s (code): %q is not an ISO 3166-2 code such as US-CA", where(i), value)
+			}
+			subdivision.Code = country + "-" + local
+			subdivision.Country = country
+
+		case RoleSubdivisionType:
+			subdivision.Type = strings.ToLower(value)
+
+		case RoleAliases:
+			for _, alias := range strings.Split(value, AliasSeparator) {
+				if alias = strings.TrimSpace(alias); alias != "" {
+					subdivision.Aliases = append(subdivision.Aliases, alias)
+				}
+			}
+
+		default: // name_<lang>
+			if value != "" {
+				subdivision.Names[strings.TrimPrefix(role, RoleNamePrefix)] = value
+			}
+		}
+	}
+
+	if len(subdivision.Names) == 0 {
+		return nil, fmt.Errorf("%s: subdivision %s has no name", where(0), subdivision.Code)
+	}
+
+	return subdivision, nil
+}
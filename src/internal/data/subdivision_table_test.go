@@ -0,0 +1,95 @@
+package data_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"country-iso-matcher/src/internal/data"
+)
+
+func TestWithSubdivisions(t *testing.T) {
+	loader, ok := data.WithSubdivisions(data.WithHistorical(data.NewMemoryLoader(), ""), "").(data.SubdivisionLoader)
+	if !ok {
+		t.Fatal("expected the loader to provide subdivisions")
+	}
+	if _, ok := loader.(data.HistoricalLoader); !ok {
+		t.Error("expected the loader to keep providing historical countries")
+	}
+
+	subdivisions, err := loader.LoadSubdivisions()
+	if err != nil {
+		t.Fatalf("failed to load embedded subdivisions: %v", err)
+	}
+	found := make(map[string]bool)
+	for _, subdivision := range subdivisions {
+		found[subdivision.Code] = true
+		if subdivision.Type == "" || subdivision.GetName() == "" {
+			t.Errorf("%s: missing type or name", subdivision.Code)
+		}
+	}
+	for _, code := range []string{"US-CA", "DE-BY", "CA-QC", "GB-SCT", "IN-TS"} {
+		if !found[code] {
+			t.Errorf("missing subdivision %s", code)
+		}
+	}
+
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		valid   bool
+	}{
+		{
+			name:    "valid",
+			file:    "valid.csv",
+			content: "code,type,name_en,name_de,aliases\nde-by,State,Bavaria,Bayern,Freistaat Bayern|Free State of Bavaria\n",
+			valid:   true,
+		},
+		{
+			name:    "tab separated",
+			file:    "valid.tsv",
+			content: "code\ttype\tname_en\nDE-BY\tstate\tBavaria\n",
+			valid:   true,
+		},
+		{
+			name:    "malformed code",
+			file:    "code.csv",
+			content: "code,type,name_en\nBY,state,Bavaria\n",
+		},
+		{
+			name:    "missing name column",
+			file:    "names.csv",
+			content: "code,type\nDE-BY,state\n",
+		},
+		{
+			name:    "duplicate code",
+			file:    "duplicate.csv",
+			content: "code,type,name_en\nDE-BY,state,Bavaria\nDE-BY,state,Bayern\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(dir, tt.file)
+			if err := os.WriteFile(file, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			subdivisions, err := data.WithSubdivisions(data.NewMemoryLoader(), file).(data.SubdivisionLoader).LoadSubdivisions()
+			if !tt.valid {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(subdivisions) != 1 || subdivisions[0].Code != "DE-BY" || subdivisions[0].Country != "DE" || subdivisions[0].Type != "state" {
+				t.Errorf("unexpected subdivisions: %+v", subdivisions)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package domain
+
+// AddressCandidate is a country an address may belong to, with the evidence for it
+type AddressCandidate struct {
+	Country       CountryInfo `json:"country"`
+	Confidence    float64     `json:"confidence"`
+	Mention       string      `json:"mention,omitempty"`       // Text of the address naming the country
+	Line          int         `json:"line,omitempty"`          // Line of the mention, counting from 1
+	PostalCode    string      `json:"postalCode,omitempty"`    // Postal code of the address in one of the country's formats
+	PostalPattern string      `json:"postalPattern,omitempty"` // The format the postal code matched
+	Explanation   []string    `json:"explanation"`             // Why the country is a candidate, one finding per entry
+}
+
+// AddressResponse is the API response for address resolution: the best candidate,
+// followed by every candidate considered, best first
+type AddressResponse struct {
+	AddressCandidate
+	Candidates []AddressCandidate `json:"candidates"`
+}
@@ -0,0 +1,42 @@
+package domain
+
+// BatchStatusOK is the status of a batch item that resolved to a country
+const BatchStatusOK = "ok"
+
+// BatchItem is the outcome of one query in a batch, at the same index as in the request
+type BatchItem struct {
+	Index  int              `json:"index"`
+	Query  string           `json:"query"`
+	Status string           `json:"status"` // ok, region, not_found, ambiguous, validation or error
+	Result *CountryResponse `json:"result,omitempty"`
+	Region *RegionInfo      `json:"region,omitempty"` // Set for locales naming a UN M49 area, see BatchStatusRegion
+	Error  *AppError        `json:"error,omitempty"`
+}
+
+// BatchSummary counts batch items by status
+type BatchSummary struct {
+	Total  int            `json:"total"`
+	Status map[string]int `json:"status"`
+}
+
+// BatchResponse is the API response for batch conversion
+type BatchResponse struct {
+	Results []BatchItem  `json:"results"`
+	Summary BatchSummary `json:"summary"`
+}
+
+// NewBatchResponse collects batch items and summarizes them by status
+func NewBatchResponse(items []BatchItem) *BatchResponse {
+	summary := BatchSummary{
+		Total:  len(items),
+		Status: make(map[string]int),
+	}
+	for _, item := range items {
+		summary.Status[item.Status]++
+	}
+
+	return &BatchResponse{
+		Results: items,
+		Summary: summary,
+	}
+}
@@ -0,0 +1,41 @@
+package domain
+
+// CatalogEntry is one country of the catalog list
+type CatalogEntry struct {
+	CountryInfo
+	Meta *MetaInfo `json:"meta,omitempty"` // Set when requested through include=meta
+}
+
+// CatalogResponse is one page of the country catalog
+type CatalogResponse struct {
+	Total     int            `json:"total"` // Countries matching the filters, on all pages
+	Offset    int            `json:"offset"`
+	Limit     int            `json:"limit"`
+	Countries []CatalogEntry `json:"countries"`
+}
+
+// CountryRecord is the full record of a country: every name, alias and code the matcher knows
+type CountryRecord struct {
+	CountryInfo
+	Names   map[string]string `json:"names"` // Language code -> Name
+	Aliases []string          `json:"aliases"`
+	Codes   map[string]string `json:"codes,omitempty"` // Code system -> code, see CodeSystems
+	Meta    *MetaInfo         `json:"meta,omitempty"`
+
+	Demonyms map[string][]Demonym `json:"demonyms,omitempty"` // Language code -> demonyms
+}
+
+// NewCountryRecord builds the full record of a country with the given aliases
+func NewCountryRecord(country *Country, aliases []string) *CountryRecord {
+	if aliases == nil {
+		aliases = []string{}
+	}
+	return &CountryRecord{
+		CountryInfo: NewCountryInfo(country),
+		Names:       country.Names,
+		Aliases:     aliases,
+		Codes:       country.Codes,
+		Meta:        NewMetaInfo(country),
+		Demonyms:    country.Demonyms,
+	}
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Code systems a country can be identified by. ISO2, ISO3 and numeric are the ISO 3166-1
+// codes every country has; the others are kept in Country.Codes when the data supplies them.
+const (
+	CodeSystemISO2    = "iso2"
+	CodeSystemISO3    = "iso3"
+	CodeSystemNumeric = "numeric"
+	CodeSystemM49     = "m49"     // UN M49; the ISO numeric code unless the data says otherwise
+	CodeSystemIOC     = "ioc"     // International Olympic Committee
+	CodeSystemFIFA    = "fifa"    // FIFA trigram
+	CodeSystemITU     = "itu"     // ITU-T E.164 calling code, without "+"
+	CodeSystemFIPS    = "fips"    // FIPS 10-4
+	CodeSystemTLD     = "tld"     // Country code top-level domain, with the leading dot
+	CodeSystemVehicle = "vehicle" // International vehicle registration code
+)
+
+// CodeSystems lists every supported code system
+var CodeSystems = []string{
+	CodeSystemISO2, CodeSystemISO3, CodeSystemNumeric, CodeSystemM49, CodeSystemIOC,
+	CodeSystemFIFA, CodeSystemITU, CodeSystemFIPS, CodeSystemTLD, CodeSystemVehicle,
+}
+
+// IsCodeSystem reports whether system is one of CodeSystems
+func IsCodeSystem(system string) bool {
+	for _, known := range CodeSystems {
+		if system == known {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeCode brings a code into the canonical form of its system, so codes from
+// different feeds compare equal: "+49" and "0049" become "49", "DE" becomes ".de",
+// "76" becomes "076" and letter codes are upper-cased. It reports false for codes
+// that cannot belong to the system.
+func NormalizeCode(system, code string) (string, bool) {
+	code = strings.TrimSpace(code)
+
+	switch system {
+	case CodeSystemNumeric, CodeSystemM49:
+		return NumericCode(code)
+
+	case CodeSystemITU:
+		code = strings.NewReplacer(" ", "", "-", "").Replace(code)
+		if strings.HasPrefix(code, "+") {
+			code = code[1:]
+		} else if strings.HasPrefix(code, "00") {
+			code = code[2:]
+		}
+		if code == "" || strings.TrimLeft(code, "0123456789") != "" {
+			return "", false
+		}
+		return code, true
+
+	case CodeSystemTLD:
+		code = strings.ToLower(strings.TrimPrefix(code, "."))
+		if code == "" || strings.ContainsAny(code, ". ") {
+			return "", false
+		}
+		return "." + code, true
+
+	default:
+		if code == "" || strings.ContainsAny(code, " \t") {
+			return "", false
+		}
+		return strings.ToUpper(code), true
+	}
+}
+
+// CodeIn returns the country's code in a code system, or "" when it has none
+func (c *Country) CodeIn(system string) string {
+	switch system {
+	case CodeSystemISO2:
+		return c.ISO2
+	case CodeSystemISO3:
+		return c.ISO3
+	case CodeSystemNumeric:
+		return c.Numeric
+	case CodeSystemM49:
+		if code, ok := c.Codes[CodeSystemM49]; ok {
+			return code
+		}
+		return c.Numeric
+	default:
+		return c.Codes[system]
+	}
+}
+
+// CodeConversionResponse is the API response for converting a code between code systems
+type CodeConversionResponse struct {
+	From    string      `json:"from"`
+	To      string      `json:"to"`
+	Code    string      `json:"code"`   // Input code in the canonical form of its system
+	Result  string      `json:"result"` // Code of the country in the target system
+	Country CountryInfo `json:"country"`
+}
+
+// NewCodeNotFoundError reports that no country has code in a code system
+func NewCodeNotFoundError(system, code string) *AppError {
+	return &AppError{
+		Code:    404,
+		Message: fmt.Sprintf("No country with %s code %s", system, code),
+		Query:   code,
+	}
+}
+
+// NewMissingCodeError reports that a country has no code in a code system
+func NewMissingCodeError(system string, country *Country) *AppError {
+	return &AppError{
+		Code:    404,
+		Message: fmt.Sprintf("%s has no %s code", country.GetOfficialName(), system),
+		Query:   country.ISO2,
+	}
+}
+
+// NewAmbiguousCodeError reports a code shared by several countries within a code system
+func NewAmbiguousCodeError(system, code string, countries []*Country) *AppError {
+	err := NewAmbiguousError(code, countries)
+	err.Message = fmt.Sprintf("Ambiguous %s code: %s is used by %d countries", system, code, len(countries))
+	return err
+}
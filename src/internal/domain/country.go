@@ -1,11 +1,23 @@
 package domain
 
+import (
+	"sort"
+	"strings"
+)
+
 // Country represents a country with its ISO codes and multilingual information
 type Country struct {
 	ISO2    string            `json:"iso2"`
 	ISO3    string            `json:"iso3"`
-	Names   map[string]string `json:"names"`   // Language code -> Name
-	Aliases []string          `json:"aliases"` // All aliases for this country
+	Numeric string            `json:"numeric,omitempty"` // ISO 3166-1 numeric code, e.g. "276"
+	Codes   map[string]string `json:"codes,omitempty"`   // Code system (ioc, fifa, itu, ...) -> code, see CodeSystems
+	Names   map[string]string `json:"names"`             // Language code -> Name
+	Aliases []string          `json:"aliases"`           // All aliases for this country
+	Meta    *CountryMeta      `json:"meta,omitempty"`    // Region, capital, currencies and languages, when known
+
+	// Demonyms are the words for the country's people, e.g. "German", by language code.
+	// They are indexed apart from names and aliases and only match nationality lookups.
+	Demonyms map[string][]Demonym `json:"demonyms,omitempty"`
 }
 
 // CountryResponse is the API response structure
@@ -13,7 +25,48 @@ type CountryResponse struct {
 	Query        string `json:"query"`
 	OfficialName string `json:"officialName"`
 	ISO2Code     string `json:"iso2Code"`
-	ISO3Code     string `json:"iso3Code"`
+	ISO3Code     string `json:"iso3Code,omitempty"`
+	NumericCode  string `json:"numericCode,omitempty"`
+	MatchType    string `json:"matchType,omitempty"`
+	MatchedName  string `json:"matchedName,omitempty"` // Set for fuzzy matches only
+	Distance     int    `json:"distance,omitempty"`    // Edit distance for fuzzy matches
+
+	// Set when the query names a withdrawn country (matchType "historical")
+	Historical *HistoricalInfo `json:"historical,omitempty"`
+
+	// Set when the query names a subdivision of the country (matchType "subdivision")
+	Subdivision *SubdivisionInfo `json:"subdivision,omitempty"`
+
+	// Set when the query is a demonym of the country (matchType "demonym"): every form
+	// it matched, e.g. both the masculine singular and plural of "Français"
+	Demonyms []DemonymInfo `json:"demonyms,omitempty"`
+
+	// Set when a language was requested through lang or Accept-Language
+	LocalizedName string `json:"localizedName,omitempty"`
+	Language      string `json:"language,omitempty"` // Language of LocalizedName after fallback
+
+	// Set when requested through include=meta and the data has metadata for the country
+	Meta *MetaInfo `json:"meta,omitempty"`
+}
+
+// NumericCode returns code as a three-digit ISO 3166-1 numeric code, restoring or
+// removing leading zeros ("76" and "0076" become "076"). It reports false when code
+// is not a number of at most three significant digits.
+func NumericCode(code string) (string, bool) {
+	if code == "" || len(code) > 8 {
+		return "", false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return "", false
+		}
+	}
+
+	significant := strings.TrimLeft(code, "0")
+	if len(significant) > 3 {
+		return "", false
+	}
+	return strings.Repeat("0", 3-len(significant)) + significant, true
 }
 
 // Legacy support for backward compatibility
@@ -21,16 +74,45 @@ func (c *Country) Code() string {
 	return c.ISO2
 }
 
-// GetOfficialName returns the English name or the first available name
+// GetOfficialName returns the English name or, without one, the name with the lowest language key
 func (c *Country) GetOfficialName() string {
-	if name, ok := c.Names["en"]; ok {
-		return name
+	name, _ := c.LocalizedName(nil)
+	return name
+}
+
+// LocalizedName returns the first name found for the language keys in chain
+// (see locale.FallbackChain), together with the language key actually used.
+// It falls back to English and then to the lowest language key, so the result is deterministic.
+func (c *Country) LocalizedName(chain []string) (string, string) {
+	return localizedName(c.Names, chain)
+}
+
+// localizedName picks the name for chain from names keyed by language, see Country.LocalizedName
+func localizedName(names map[string]string, chain []string) (string, string) {
+	for _, lang := range chain {
+		if name, ok := names[lang]; ok {
+			return name, lang
+		}
+		if lower := strings.ToLower(lang); lower != lang {
+			if name, ok := names[lower]; ok {
+				return name, lower
+			}
+		}
 	}
-	// Fallback to first available name
-	for _, name := range c.Names {
-		return name
+
+	if name, ok := names["en"]; ok {
+		return name, "en"
 	}
-	return ""
+
+	langs := make([]string, 0, len(names))
+	for lang := range names {
+		langs = append(langs, lang)
+	}
+	if len(langs) == 0 {
+		return "", ""
+	}
+	sort.Strings(langs)
+	return names[langs[0]], langs[0]
 }
 
 func NewCountryResponse(query string, country *Country) *CountryResponse {
@@ -39,5 +121,130 @@ func NewCountryResponse(query string, country *Country) *CountryResponse {
 		OfficialName: country.GetOfficialName(),
 		ISO2Code:     country.ISO2,
 		ISO3Code:     country.ISO3,
+		NumericCode:  country.Numeric,
+	}
+}
+
+// NewMatchResponse builds a response from a repository match, flagging fuzzy, historical and
+// subdivision matches
+func NewMatchResponse(query string, match *Match) *CountryResponse {
+	response := NewCountryResponse(query, match.Country)
+	response.MatchType = string(match.Type)
+	if match.Type == MatchTypeFuzzy {
+		response.MatchedName = match.MatchedName
+		response.Distance = match.Distance
 	}
+	response.Historical = NewHistoricalInfo(match)
+	response.Demonyms = match.Demonyms
+	if match.Subdivision != nil {
+		info := NewSubdivisionInfo(match.Subdivision, nil)
+		response.Subdivision = &info
+	}
+	return response
+}
+
+// Localize fills in the country name for the first available language in chain
+func (r *CountryResponse) Localize(country *Country, chain []string) {
+	r.LocalizedName, r.Language = country.LocalizedName(chain)
+}
+
+// LocalizeMatch localizes the country of a match and, for subdivision matches, the subdivision
+func (r *CountryResponse) LocalizeMatch(match *Match, chain []string) {
+	r.Localize(match.Country, chain)
+	if match.Subdivision != nil {
+		info := NewSubdivisionInfo(match.Subdivision, chain)
+		r.Subdivision = &info
+	}
+}
+
+// AddMeta adds the metadata block of the country
+func (r *CountryResponse) AddMeta(country *Country) {
+	r.Meta = NewMetaInfo(country)
+}
+
+// CountryResponseV2 is the v2 API response. Besides the country it reports how the query
+// matched and which names, aliases or codes in the data produced the match.
+type CountryResponseV2 struct {
+	Query       string           `json:"query"`
+	Country     CountryInfo      `json:"country"`
+	Match       MatchInfo        `json:"match"`
+	Historical  *HistoricalInfo  `json:"historical,omitempty"`  // Set for historical matches
+	Subdivision *SubdivisionInfo `json:"subdivision,omitempty"` // Set for subdivision matches
+	Demonyms    []DemonymInfo    `json:"demonyms,omitempty"`    // Set for demonym matches
+	Meta        *MetaInfo        `json:"meta,omitempty"`        // Set when requested through include=meta
+}
+
+// CountryInfo identifies a country in v2 responses
+type CountryInfo struct {
+	OfficialName  string `json:"officialName"`
+	ISO2Code      string `json:"iso2Code"`
+	ISO3Code      string `json:"iso3Code,omitempty"`
+	NumericCode   string `json:"numericCode,omitempty"`
+	LocalizedName string `json:"localizedName,omitempty"`
+	Language      string `json:"language,omitempty"`
+}
+
+// NewCountryInfo identifies a country in v2 responses, without a localized name
+func NewCountryInfo(country *Country) CountryInfo {
+	return CountryInfo{
+		OfficialName: country.GetOfficialName(),
+		ISO2Code:     country.ISO2,
+		ISO3Code:     country.ISO3,
+		NumericCode:  country.Numeric,
+	}
+}
+
+// Localize fills in the country name for the first available language in chain
+func (i *CountryInfo) Localize(country *Country, chain []string) {
+	i.LocalizedName, i.Language = country.LocalizedName(chain)
+}
+
+// MatchInfo describes how a v2 query was resolved
+type MatchInfo struct {
+	Type            MatchType    `json:"type"`
+	NormalizedQuery string       `json:"normalizedQuery"`
+	MatchedKey      string       `json:"matchedKey"`
+	Distance        int          `json:"distance,omitempty"`
+	Provenance      []Provenance `json:"provenance"`
+}
+
+// NewCountryResponseV2 builds a v2 response from a repository match
+func NewCountryResponseV2(query string, match *Match) *CountryResponseV2 {
+	response := &CountryResponseV2{
+		Query:   query,
+		Country: NewCountryInfo(match.Country),
+		Match: MatchInfo{
+			Type:            match.Type,
+			NormalizedQuery: match.NormalizedQuery,
+			MatchedKey:      match.MatchedName,
+			Distance:        match.Distance,
+			Provenance:      match.Provenance,
+		},
+		Historical: NewHistoricalInfo(match),
+		Demonyms:   match.Demonyms,
+	}
+	if match.Subdivision != nil {
+		info := NewSubdivisionInfo(match.Subdivision, nil)
+		response.Subdivision = &info
+	}
+	return response
+}
+
+// Localize fills in the country name for the first available language in chain
+func (r *CountryResponseV2) Localize(country *Country, chain []string) {
+	r.Country.Localize(country, chain)
+}
+
+// LocalizeMatch localizes the country of a match and, for subdivision matches, the subdivision
+func (r *CountryResponseV2) LocalizeMatch(match *Match, chain []string) {
+	r.Localize(match.Country, chain)
+	if match.Subdivision != nil {
+		info := NewSubdivisionInfo(match.Subdivision, chain)
+		r.Subdivision = &info
+	}
+}
+
+// AddMeta adds the metadata block of the country
+func (r *CountryResponseV2) AddMeta(country *Country) {
+	r.Meta = NewMetaInfo(country)
 }
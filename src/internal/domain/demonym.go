@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Grammatical genders of demonyms
+const (
+	GenderMasculine = "masculine"
+	GenderFeminine  = "feminine"
+	GenderNeuter    = "neuter"
+)
+
+// DemonymSeparator separates the forms of a demonym list in text form
+const DemonymSeparator = "|"
+
+// demonymTags are the tags of the text form, see ParseDemonyms
+var demonymTags = map[string]string{
+	"m": GenderMasculine, "masc": GenderMasculine, GenderMasculine: GenderMasculine,
+	"f": GenderFeminine, "fem": GenderFeminine, GenderFeminine: GenderFeminine,
+	"n": GenderNeuter, "neut": GenderNeuter, GenderNeuter: GenderNeuter,
+}
+
+// Demonym is one form of the word for the people of a country in one language, e.g.
+// "German", "Deutsche" or "Françaises". Languages with grammatical gender have a form
+// per gender and number; adjectives used for nationalities (e.g. "deutsch") are forms
+// without gender.
+type Demonym struct {
+	Text   string `json:"text"`
+	Gender string `json:"gender,omitempty"` // One of the Gender constants; empty when the form serves every gender
+	Plural bool   `json:"plural,omitempty"`
+}
+
+// ParseDemonyms parses demonym forms separated by DemonymSeparator. A form may end with
+// its gender and number in parentheses: m, f or n for the gender and pl for the plural,
+// e.g. "Français (m)|Française (f)|Françaises (f pl)".
+func ParseDemonyms(value string) ([]Demonym, error) {
+	var demonyms []Demonym
+	for _, form := range strings.Split(value, DemonymSeparator) {
+		form = strings.TrimSpace(form)
+		if form == "" {
+			continue
+		}
+
+		demonym := Demonym{Text: form}
+		if open := strings.LastIndex(form, "("); open > 0 && strings.HasSuffix(form, ")") {
+			demonym.Text = strings.TrimSpace(form[:open])
+			for _, tag := range strings.Fields(form[open+1 : len(form)-1]) {
+				tag = strings.ToLower(tag)
+				if tag == "pl" || tag == "plural" {
+					demonym.Plural = true
+					continue
+				}
+				gender, known := demonymTags[tag]
+				if !known {
+					return nil, fmt.Errorf("unknown demonym tag %q in %q (use m, f, n or pl)", tag, form)
+				}
+				demonym.Gender = gender
+			}
+		}
+		if demonym.Text == "" {
+			return nil, fmt.Errorf("demonym %q has no text", form)
+		}
+		demonyms = append(demonyms, demonym)
+	}
+	return demonyms, nil
+}
+
+// FormatDemonyms is the text form of demonyms, as read by ParseDemonyms
+func FormatDemonyms(demonyms []Demonym) string {
+	forms := make([]string, 0, len(demonyms))
+	for _, demonym := range demonyms {
+		var tags []string
+		if demonym.Gender != "" {
+			tags = append(tags, demonym.Gender[:1])
+		}
+		if demonym.Plural {
+			tags = append(tags, "pl")
+		}
+		if len(tags) == 0 {
+			forms = append(forms, demonym.Text)
+			continue
+		}
+		forms = append(forms, fmt.Sprintf("%s (%s)", demonym.Text, strings.Join(tags, " ")))
+	}
+	return strings.Join(forms, DemonymSeparator)
+}
+
+// NormalizeDemonyms trims the demonyms of every language and canonicalizes their genders,
+// accepting the tags of ParseDemonyms. It fails on forms without text or unknown genders.
+func NormalizeDemonyms(demonyms map[string][]Demonym) error {
+	for lang, forms := range demonyms {
+		for i := range forms {
+			forms[i].Text = strings.TrimSpace(forms[i].Text)
+			if forms[i].Text == "" {
+				return fmt.Errorf("demonym %d in %s has no text", i+1, lang)
+			}
+			if forms[i].Gender == "" {
+				continue
+			}
+			gender, known := demonymTags[strings.ToLower(strings.TrimSpace(forms[i].Gender))]
+			if !known {
+				return fmt.Errorf("demonym %q in %s has unknown gender %q", forms[i].Text, lang, forms[i].Gender)
+			}
+			forms[i].Gender = gender
+		}
+	}
+	return nil
+}
+
+// DemonymInfo is a demonym of a country that matched a query, in API responses
+type DemonymInfo struct {
+	Demonym
+	Language string `json:"language"`
+}
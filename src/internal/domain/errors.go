@@ -3,9 +3,20 @@ package domain
 import "fmt"
 
 type AppError struct {
-	Code    int    `json:"-"`
-	Message string `json:"error"`
-	Query   string `json:"query,omitempty"`
+	Code       int                `json:"-"`
+	Message    string             `json:"error"`
+	Query      string             `json:"query,omitempty"`
+	Candidates []CountryCandidate `json:"candidates,omitempty"` // Set for ambiguous queries
+
+	// Set for ambiguous subdivision queries
+	SubdivisionCandidates []SubdivisionInfo `json:"subdivisionCandidates,omitempty"`
+}
+
+// CountryCandidate is one of several countries an ambiguous query could refer to
+type CountryCandidate struct {
+	OfficialName string `json:"officialName"`
+	ISO2Code     string `json:"iso2Code"`
+	ISO3Code     string `json:"iso3Code,omitempty"`
 }
 
 func (e *AppError) Error() string {
@@ -20,6 +31,14 @@ func NewValidationError(message, query string) *AppError {
 	}
 }
 
+// NewTooLargeError reports a request body over its size limit
+func NewTooLargeError(message string) *AppError {
+	return &AppError{
+		Code:    413,
+		Message: message,
+	}
+}
+
 func NewNotFoundError(query string) *AppError {
 	return &AppError{
 		Code:    404,
@@ -28,9 +47,41 @@ func NewNotFoundError(query string) *AppError {
 	}
 }
 
+func NewAmbiguousError(query string, countries []*Country) *AppError {
+	candidates := make([]CountryCandidate, 0, len(countries))
+	for _, country := range countries {
+		candidates = append(candidates, CountryCandidate{
+			OfficialName: country.GetOfficialName(),
+			ISO2Code:     country.ISO2,
+			ISO3Code:     country.ISO3,
+		})
+	}
+
+	return &AppError{
+		Code:       409,
+		Message:    fmt.Sprintf("Ambiguous country name: %s", query),
+		Query:      query,
+		Candidates: candidates,
+	}
+}
+
 func NewInternalError(message string) *AppError {
 	return &AppError{
 		Code:    500,
 		Message: message,
 	}
 }
+
+// ErrorStatus returns the short machine-readable status used for per-item results in bulk endpoints
+func ErrorStatus(err *AppError) string {
+	switch err.Code {
+	case 400:
+		return "validation"
+	case 404:
+		return "not_found"
+	case 409:
+		return "ambiguous"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,51 @@
+package domain
+
+// Extraction is a country mention the repository found in a free text
+type Extraction struct {
+	Text  string // The mention as written in the text
+	Start int    // Character offset of the mention's first character
+	End   int    // Character offset just after the mention's last character
+	Match *Match // Score is the confidence that the mention names the country
+}
+
+// Mention is a country mention in extraction responses. Offsets count Unicode characters
+// (code points), not bytes.
+type Mention struct {
+	Text        string           `json:"text"`
+	Start       int              `json:"start"`
+	End         int              `json:"end"`
+	Country     CountryInfo      `json:"country"`
+	MatchType   MatchType        `json:"matchType"`
+	Confidence  float64          `json:"confidence"`
+	Historical  *HistoricalInfo  `json:"historical,omitempty"`  // Set for historical mentions
+	Subdivision *SubdivisionInfo `json:"subdivision,omitempty"` // Set for subdivision mentions
+}
+
+// NewMention builds the response entry for an extraction, localizing names for the first
+// available language in chain when chain is not empty
+func NewMention(extraction Extraction, chain []string) Mention {
+	match := extraction.Match
+	mention := Mention{
+		Text:       extraction.Text,
+		Start:      extraction.Start,
+		End:        extraction.End,
+		Country:    NewCountryInfo(match.Country),
+		MatchType:  match.Type,
+		Confidence: match.Score,
+		Historical: NewHistoricalInfo(match),
+	}
+	if len(chain) > 0 {
+		mention.Country.Localize(match.Country, chain)
+	}
+	if match.Subdivision != nil {
+		info := NewSubdivisionInfo(match.Subdivision, chain)
+		mention.Subdivision = &info
+	}
+	return mention
+}
+
+// ExtractResponse is the API response for country extraction
+type ExtractResponse struct {
+	Mentions  []Mention `json:"mentions"`  // In order of appearance
+	Countries []string  `json:"countries"` // ISO2 codes of the mentioned countries, in order of first mention
+}
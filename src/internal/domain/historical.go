@@ -0,0 +1,80 @@
+package domain
+
+// HistoricalCountry is a country whose code was withdrawn from ISO 3166-1, as listed in
+// ISO 3166-3, e.g. the USSR or Yugoslavia
+type HistoricalCountry struct {
+	Code       string            `json:"code"`              // ISO 3166-3 four-letter code, e.g. "SUHH"
+	ISO2       string            `json:"iso2"`              // Former alpha-2 code, e.g. "SU"
+	ISO3       string            `json:"iso3"`              // Former alpha-3 code, e.g. "SUN"
+	Numeric    string            `json:"numeric,omitempty"` // Former numeric code, e.g. "810"
+	Names      map[string]string `json:"names"`             // Language code -> Name
+	Aliases    []string          `json:"aliases,omitempty"`
+	ValidFrom  string            `json:"validFrom,omitempty"` // Year or date the code was assigned
+	ValidTo    string            `json:"validTo"`             // Year or date the code was withdrawn
+	Successors []string          `json:"successors"`          // ISO2 codes of the current countries that replaced it
+}
+
+// IsHistoricalCode reports whether code has the form of an ISO 3166-3 code: four upper-case letters
+func IsHistoricalCode(code string) bool {
+	if len(code) != 4 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// AsCountry returns the historical country as a country record, so it can be reported
+// wherever a country is expected
+func (h *HistoricalCountry) AsCountry() *Country {
+	return &Country{
+		ISO2:    h.ISO2,
+		ISO3:    h.ISO3,
+		Numeric: h.Numeric,
+		Names:   h.Names,
+		Aliases: h.Aliases,
+	}
+}
+
+// HistoricalInfo describes the withdrawn country a query matched, in API responses
+type HistoricalInfo struct {
+	Code         string             `json:"code"`
+	OfficialName string             `json:"officialName"`
+	ISO2Code     string             `json:"iso2Code"`
+	ISO3Code     string             `json:"iso3Code"`
+	NumericCode  string             `json:"numericCode,omitempty"`
+	ValidFrom    string             `json:"validFrom,omitempty"`
+	ValidTo      string             `json:"validTo"`
+	Successors   []CountryCandidate `json:"successors"`
+}
+
+// NewHistoricalInfo builds the response block for a historical match
+func NewHistoricalInfo(match *Match) *HistoricalInfo {
+	if match.Historical == nil {
+		return nil
+	}
+
+	h := match.Historical
+	successors := make([]CountryCandidate, 0, len(match.Successors))
+	for _, country := range match.Successors {
+		successors = append(successors, CountryCandidate{
+			OfficialName: country.GetOfficialName(),
+			ISO2Code:     country.ISO2,
+			ISO3Code:     country.ISO3,
+		})
+	}
+
+	return &HistoricalInfo{
+		Code:         h.Code,
+		OfficialName: h.AsCountry().GetOfficialName(),
+		ISO2Code:     h.ISO2,
+		ISO3Code:     h.ISO3,
+		NumericCode:  h.Numeric,
+		ValidFrom:    h.ValidFrom,
+		ValidTo:      h.ValidTo,
+		Successors:   successors,
+	}
+}
@@ -0,0 +1,22 @@
+package domain
+
+// BatchStatusRegion is the status of a batch item whose locale names a UN M49 area
+// (e.g. es-419) instead of a country
+const BatchStatusRegion = "region"
+
+// RegionInfo is a UN M49 area named by the region subtag of a locale, e.g. 419 for Latin America
+type RegionInfo struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// LocaleResponse is the API response for locale resolution
+type LocaleResponse struct {
+	Query    string       `json:"query"`
+	Tag      string       `json:"tag"` // Canonical BCP 47 form of the query
+	Language string       `json:"language,omitempty"`
+	Script   string       `json:"script,omitempty"`
+	Country  *CountryInfo `json:"country,omitempty"` // Set when the locale names or implies a country
+	Region   *RegionInfo  `json:"region,omitempty"`  // Set when the region subtag is a UN M49 area
+	Likely   bool         `json:"likely,omitempty"`  // Set when the country was inferred from the language
+}
@@ -0,0 +1,134 @@
+package domain
+
+// MatchType describes how a query was resolved to a country
+type MatchType string
+
+const (
+	// MatchTypeExact means the normalized query is one of the country's names
+	MatchTypeExact MatchType = "exact"
+	// MatchTypeAlias means the normalized query is a known alias of the country
+	MatchTypeAlias MatchType = "alias"
+	// MatchTypeCode means the query is one of the country's ISO codes (alpha-2, alpha-3 or numeric)
+	MatchTypeCode MatchType = "code"
+	// MatchTypeFuzzy means the query was resolved by typo-tolerant matching
+	MatchTypeFuzzy MatchType = "fuzzy"
+	// MatchTypePrefix means the query is the beginning of a name or alias
+	MatchTypePrefix MatchType = "prefix"
+	// MatchTypeSubdivision means the query names a subdivision (ISO 3166-2) of the country
+	MatchTypeSubdivision MatchType = "subdivision"
+	// MatchTypeHistorical means the query names a country withdrawn from ISO 3166-1 (see ISO 3166-3)
+	MatchTypeHistorical MatchType = "historical"
+	// MatchTypeDemonym means the query is a word for the country's people, e.g. "German"
+	MatchTypeDemonym MatchType = "demonym"
+	// MatchTypeLocale means the query is a locale identifier whose region subtag, or likely
+	// region, is the country's alpha-2 code, e.g. "pt_BR.UTF-8"
+	MatchTypeLocale MatchType = "locale"
+)
+
+// MatchSource identifies the field of a country record an index key came from
+type MatchSource string
+
+const (
+	MatchSourceName    MatchSource = "name"
+	MatchSourceAlias   MatchSource = "alias"
+	MatchSourceISO2    MatchSource = "iso2"
+	MatchSourceISO3    MatchSource = "iso3"
+	MatchSourceNumeric MatchSource = "numeric"
+
+	// MatchSourceHistoricalCode is the ISO 3166-3 four-letter code of a historical country
+	MatchSourceHistoricalCode MatchSource = "historical_code"
+
+	// MatchSourceSubdivisionCode is the ISO 3166-2 code of a subdivision or its part after the country
+	MatchSourceSubdivisionCode MatchSource = "subdivision_code"
+
+	// MatchSourceDemonym is a demonym of a country, see Country.Demonyms
+	MatchSourceDemonym MatchSource = "demonym"
+)
+
+// Provenance records where an indexed key came from
+type Provenance struct {
+	Source   MatchSource `json:"source"`
+	Language string      `json:"language,omitempty"` // Language key for names
+	Original string      `json:"original"`           // Value as it appears in the data, before normalization
+}
+
+// Match is the result of resolving a query against the country index
+type Match struct {
+	Country         *Country
+	Type            MatchType
+	NormalizedQuery string       // Query after normalization
+	MatchedName     string       // Normalized index key that matched the query
+	Provenance      []Provenance // Data values behind MatchedName, most specific first
+	Distance        int          // Edit distance between query and MatchedName (fuzzy matches only)
+	Score           float64      // Confidence between 0 and 1, used to rank suggestions
+
+	// Set for historical matches. Country is then the configured successor, or the
+	// historical country itself when none is configured.
+	Historical *HistoricalCountry
+	Successors []*Country // Current countries that replaced Historical, sorted by ISO2
+
+	// Set for subdivision lookups and subdivision matches; Country is then its country
+	Subdivision *Subdivision
+
+	// Set for demonym matches: the forms of the country that normalize to MatchedName
+	Demonyms []DemonymInfo
+}
+
+// Suggestion is a ranked candidate country for a query
+type Suggestion struct {
+	OfficialName string    `json:"officialName"`
+	ISO2Code     string    `json:"iso2Code"`
+	ISO3Code     string    `json:"iso3Code,omitempty"`
+	Score        float64   `json:"score"`
+	MatchedName  string    `json:"matchedName"`
+	MatchType    MatchType `json:"matchType"`
+}
+
+// SuggestResponse is the API response for candidate suggestions
+type SuggestResponse struct {
+	Query       string       `json:"query"`
+	Suggestions []Suggestion `json:"suggestions"`
+}
+
+// NewSuggestion builds a suggestion from a ranked repository match
+func NewSuggestion(match *Match) Suggestion {
+	return Suggestion{
+		OfficialName: match.Country.GetOfficialName(),
+		ISO2Code:     match.Country.ISO2,
+		ISO3Code:     match.Country.ISO3,
+		Score:        match.Score,
+		MatchedName:  match.MatchedName,
+		MatchType:    match.Type,
+	}
+}
+
+// AutocompleteItem is a country offered for a typed prefix
+type AutocompleteItem struct {
+	Name        string    `json:"name"`     // Display name in the requested language
+	Language    string    `json:"language"` // Language of Name after fallback
+	ISO2Code    string    `json:"iso2Code"`
+	ISO3Code    string    `json:"iso3Code,omitempty"`
+	MatchedName string    `json:"matchedName"`
+	MatchType   MatchType `json:"matchType"`
+}
+
+// AutocompleteResponse is the API response for prefix autocomplete
+type AutocompleteResponse struct {
+	Prefix   string             `json:"prefix"`
+	Language string             `json:"language"`
+	Results  []AutocompleteItem `json:"results"`
+}
+
+// NewAutocompleteItem builds an autocomplete result with the country name localized
+// for the first available language in chain
+func NewAutocompleteItem(match *Match, chain []string) AutocompleteItem {
+	name, lang := match.Country.LocalizedName(chain)
+	return AutocompleteItem{
+		Name:        name,
+		Language:    lang,
+		ISO2Code:    match.Country.ISO2,
+		ISO3Code:    match.Country.ISO3,
+		MatchedName: match.MatchedName,
+		MatchType:   match.Type,
+	}
+}
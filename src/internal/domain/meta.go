@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Metadata fields, named as in meta_<field> columns and the database schema's meta_columns
+const (
+	MetaContinent  = "continent"
+	MetaRegion     = "region"
+	MetaSubregion  = "subregion"
+	MetaCapital    = "capital"
+	MetaCurrencies = "currencies"
+	MetaLanguages  = "languages"
+)
+
+// MetaFields lists every metadata field
+var MetaFields = []string{MetaContinent, MetaRegion, MetaSubregion, MetaCapital, MetaCurrencies, MetaLanguages}
+
+// MetaListSeparator separates the entries of list fields (currencies, languages) in text form
+const MetaListSeparator = "|"
+
+// CountryMeta is reference data about a country beyond its names and codes. The calling
+// code and TLD are code systems (see CodeSystems) and live in Country.Codes.
+type CountryMeta struct {
+	Continent  string   `json:"continent,omitempty"`  // e.g. "Europe", "North America"
+	Region     string   `json:"region,omitempty"`     // UN M49 region, e.g. "Americas"
+	Subregion  string   `json:"subregion,omitempty"`  // UN M49 subregion, e.g. "Western Europe"
+	Capital    string   `json:"capital,omitempty"`    // English name of the capital
+	Currencies []string `json:"currencies,omitempty"` // ISO 4217 codes, main currency first
+	Languages  []string `json:"languages,omitempty"`  // Official languages, ISO 639 codes
+}
+
+// IsMetaField reports whether field is one of MetaFields
+func IsMetaField(field string) bool {
+	for _, known := range MetaFields {
+		if field == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Set assigns a field from its text form. List fields take entries separated by
+// MetaListSeparator; currencies are upper-cased and languages lower-cased.
+func (m *CountryMeta) Set(field, value string) error {
+	value = strings.TrimSpace(value)
+	switch field {
+	case MetaContinent:
+		m.Continent = value
+	case MetaRegion:
+		m.Region = value
+	case MetaSubregion:
+		m.Subregion = value
+	case MetaCapital:
+		m.Capital = value
+	case MetaCurrencies:
+		m.Currencies = splitMetaList(value)
+	case MetaLanguages:
+		m.Languages = splitMetaList(value)
+	default:
+		return fmt.Errorf("unknown metadata field %q (must be one of %s)", field, strings.Join(MetaFields, ", "))
+	}
+	return m.Normalize()
+}
+
+// Normalize canonicalizes and checks the list fields: currencies must be three-letter
+// ISO 4217 codes and languages two- or three-letter ISO 639 codes
+func (m *CountryMeta) Normalize() error {
+	for i, currency := range m.Currencies {
+		currency = strings.ToUpper(strings.TrimSpace(currency))
+		if !isASCIILetters(currency, 3, 3) {
+			return fmt.Errorf("%q is not an ISO 4217 currency code", currency)
+		}
+		m.Currencies[i] = currency
+	}
+	for i, language := range m.Languages {
+		language = strings.ToLower(strings.TrimSpace(language))
+		if !isASCIILetters(language, 2, 3) {
+			return fmt.Errorf("%q is not an ISO 639 language code", language)
+		}
+		m.Languages[i] = language
+	}
+	return nil
+}
+
+// IsEmpty reports whether no field is set
+func (m *CountryMeta) IsEmpty() bool {
+	return m == nil || (m.Continent == "" && m.Region == "" && m.Subregion == "" && m.Capital == "" &&
+		len(m.Currencies) == 0 && len(m.Languages) == 0)
+}
+
+// Get returns a field in its text form, with the entries of list fields separated by
+// MetaListSeparator. A nil CountryMeta has no fields set.
+func (m *CountryMeta) Get(field string) string {
+	if m == nil {
+		return ""
+	}
+	switch field {
+	case MetaContinent:
+		return m.Continent
+	case MetaRegion:
+		return m.Region
+	case MetaSubregion:
+		return m.Subregion
+	case MetaCapital:
+		return m.Capital
+	case MetaCurrencies:
+		return strings.Join(m.Currencies, MetaListSeparator)
+	case MetaLanguages:
+		return strings.Join(m.Languages, MetaListSeparator)
+	default:
+		return ""
+	}
+}
+
+// MetaInfo is the metadata block of API responses. It adds the calling code and TLD
+// from the country's codes to CountryMeta.
+type MetaInfo struct {
+	Continent   string   `json:"continent,omitempty"`
+	Region      string   `json:"region,omitempty"`
+	Subregion   string   `json:"subregion,omitempty"`
+	Capital     string   `json:"capital,omitempty"`
+	Currencies  []string `json:"currencies,omitempty"`
+	CallingCode string   `json:"callingCode,omitempty"` // E.164 country calling code, e.g. "49"
+	Languages   []string `json:"languages,omitempty"`
+	TLD         string   `json:"tld,omitempty"` // e.g. ".de"
+}
+
+// NewMetaInfo builds the metadata block for a country. It returns nil when the data
+// knows nothing about the country beyond its names.
+func NewMetaInfo(country *Country) *MetaInfo {
+	info := &MetaInfo{
+		CallingCode: country.CodeIn(CodeSystemITU),
+		TLD:         country.CodeIn(CodeSystemTLD),
+	}
+	if meta := country.Meta; meta != nil {
+		info.Continent = meta.Continent
+		info.Region = meta.Region
+		info.Subregion = meta.Subregion
+		info.Capital = meta.Capital
+		info.Currencies = meta.Currencies
+		info.Languages = meta.Languages
+	}
+
+	if country.Meta.IsEmpty() && info.CallingCode == "" && info.TLD == "" {
+		return nil
+	}
+	return info
+}
+
+// splitMetaList splits the text form of a list field, dropping empty entries
+func splitMetaList(value string) []string {
+	var entries []string
+	for _, entry := range strings.Split(value, MetaListSeparator) {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// isASCIILetters reports whether value consists of minLength to maxLength ASCII letters
+func isASCIILetters(value string, minLength, maxLength int) bool {
+	if len(value) < minLength || len(value) > maxLength {
+		return false
+	}
+	for _, r := range value {
+		if (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') {
+			return false
+		}
+	}
+	return true
+}
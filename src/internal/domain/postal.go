@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// PostalCodeFormat is one format of the postal codes of a country, e.g. \d{5} for Germany.
+// A country can have several.
+type PostalCodeFormat struct {
+	Country string `json:"country"`           // ISO2 code
+	Pattern string `json:"pattern"`           // Regular expression matching a whole postal code in capitals
+	Example string `json:"example,omitempty"` // A postal code in the format, checked by Compile
+
+	regexp *regexp.Regexp
+}
+
+// Compile prepares the pattern for Matches. It fails on an invalid pattern or an
+// example the pattern does not match.
+func (f *PostalCodeFormat) Compile() error {
+	re, err := regexp.Compile(`^(?:` + f.Pattern + `)$`)
+	if err != nil {
+		return fmt.Errorf("invalid postal code pattern %q for %s: %w", f.Pattern, f.Country, err)
+	}
+	f.regexp = re
+
+	if f.Example != "" && !f.Matches(f.Example) {
+		return fmt.Errorf("postal code pattern %q for %s does not match its example %q", f.Pattern, f.Country, f.Example)
+	}
+	return nil
+}
+
+// Matches reports whether code, compared in capitals, is a postal code in the format.
+// Formats that were not compiled match nothing.
+func (f *PostalCodeFormat) Matches(code string) bool {
+	return f.regexp != nil && f.regexp.MatchString(strings.ToUpper(strings.TrimSpace(code)))
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Subdivision is a principal subdivision of a country as listed in ISO 3166-2,
+// such as a state, province or region
+type Subdivision struct {
+	Code    string            `json:"code"`    // ISO 3166-2 code, e.g. "US-CA"
+	Country string            `json:"country"` // ISO2 code of the country, the part of Code before the hyphen
+	Type    string            `json:"type"`    // e.g. "state", "province", "region"
+	Names   map[string]string `json:"names"`   // Language code -> Name
+	Aliases []string          `json:"aliases,omitempty"`
+}
+
+// SplitSubdivisionCode splits an ISO 3166-2 code into the ISO2 code of the country and
+// the local part ("US-CA" becomes "US" and "CA"). It reports false when code does not
+// have that form. The result is upper case.
+func SplitSubdivisionCode(code string) (string, string, bool) {
+	country, local, found := strings.Cut(strings.ToUpper(strings.TrimSpace(code)), "-")
+	if !found || len(country) != 2 || len(local) == 0 || len(local) > 3 {
+		return "", "", false
+	}
+	for _, r := range country {
+		if r < 'A' || r > 'Z' {
+			return "", "", false
+		}
+	}
+	for _, r := range local {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return "", "", false
+		}
+	}
+	return country, local, true
+}
+
+// LocalCode returns the part of the code after the country, e.g. "CA" for "US-CA"
+func (s *Subdivision) LocalCode() string {
+	_, local, _ := strings.Cut(s.Code, "-")
+	return local
+}
+
+// GetName returns the English name or, without one, the name with the lowest language key
+func (s *Subdivision) GetName() string {
+	name, _ := localizedName(s.Names, nil)
+	return name
+}
+
+// LocalizedName returns the first name found for the language keys in chain, like
+// Country.LocalizedName
+func (s *Subdivision) LocalizedName(chain []string) (string, string) {
+	return localizedName(s.Names, chain)
+}
+
+// SubdivisionInfo identifies a subdivision in API responses
+type SubdivisionInfo struct {
+	Code          string `json:"code"`
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	LocalizedName string `json:"localizedName,omitempty"`
+	Language      string `json:"language,omitempty"`
+}
+
+// NewSubdivisionInfo builds the response block for a subdivision, localized for the first
+// available language in chain when chain is not empty
+func NewSubdivisionInfo(subdivision *Subdivision, chain []string) SubdivisionInfo {
+	info := SubdivisionInfo{
+		Code: subdivision.Code,
+		Name: subdivision.GetName(),
+		Type: subdivision.Type,
+	}
+	if len(chain) > 0 {
+		info.LocalizedName, info.Language = subdivision.LocalizedName(chain)
+	}
+	return info
+}
+
+// SubdivisionResponse is the API response for a subdivision lookup
+type SubdivisionResponse struct {
+	Query       string          `json:"query"`
+	Subdivision SubdivisionInfo `json:"subdivision"`
+	Country     CountryInfo     `json:"country"`
+	MatchType   MatchType       `json:"matchType"`
+}
+
+// SubdivisionListResponse is the API response listing the subdivisions of a country
+type SubdivisionListResponse struct {
+	Country      CountryInfo       `json:"country"`
+	Subdivisions []SubdivisionInfo `json:"subdivisions"`
+}
+
+// NewSubdivisionNotFoundError reports a query that matches no subdivision
+func NewSubdivisionNotFoundError(query string) *AppError {
+	return &AppError{
+		Code:    404,
+		Message: fmt.Sprintf("Subdivision not found: %s", query),
+		Query:   query,
+	}
+}
+
+// NewAmbiguousSubdivisionError reports a name or code shared by several subdivisions
+func NewAmbiguousSubdivisionError(query string, subdivisions []*Subdivision) *AppError {
+	candidates := make([]SubdivisionInfo, 0, len(subdivisions))
+	for _, subdivision := range subdivisions {
+		candidates = append(candidates, NewSubdivisionInfo(subdivision, nil))
+	}
+
+	return &AppError{
+		Code:                  409,
+		Message:               fmt.Sprintf("Ambiguous subdivision: %s", query),
+		Query:                 query,
+		SubdivisionCandidates: candidates,
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"country-iso-matcher/src/internal/config"
 	"country-iso-matcher/src/internal/data"
 	"country-iso-matcher/src/internal/handler"
+	"country-iso-matcher/src/internal/reload"
 	"country-iso-matcher/src/internal/repository/memory"
 	"country-iso-matcher/src/internal/server"
 	"country-iso-matcher/src/internal/service"
@@ -17,6 +18,10 @@ import (
 type ApplicationFactory struct {
 	config *config.Config
 	logger *slog.Logger
+
+	// Shared by the HTTP and gRPC servers
+	countryService service.CountryService
+	reloader       *reload.Reloader
 }
 
 // NewApplicationFactory creates a new application factory
@@ -36,8 +41,37 @@ func NewApplicationFactory(cfg *config.Config, logger *slog.Logger) (*Applicatio
 
 // CreateHTTPServer creates and configures the HTTP server
 func (f *ApplicationFactory) CreateHTTPServer() (server.Server, error) {
+	countryService, err := f.CountryService()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create handlers
+	countryHandler := handler.NewCountryHandler(countryService, &f.config.API, f.logger)
+	adminHandler := handler.NewAdminHandler(f.reloader, f.logger)
+
+	// Create and return HTTP server
+	return server.NewHTTPServer(f.config, countryHandler, adminHandler, countryService, f.logger), nil
+}
+
+// CreateGRPCServer creates the gRPC server, which listens on its own port next to the HTTP server
+func (f *ApplicationFactory) CreateGRPCServer() (server.Server, error) {
+	countryService, err := f.CountryService()
+	if err != nil {
+		return nil, err
+	}
+
+	return server.NewGRPCServer(f.config, countryService, f.logger), nil
+}
+
+// CountryService loads the country data on first use and returns the service built on it
+func (f *ApplicationFactory) CountryService() (service.CountryService, error) {
+	if f.countryService != nil {
+		return f.countryService, nil
+	}
+
 	// Create data loader based on configuration
-	loader, err := data.NewLoader(&f.config.Data)
+	loader, err := data.NewLoader(&f.config.Data, &f.config.Database, f.logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create data loader: %w", err)
 	}
@@ -46,17 +80,22 @@ func (f *ApplicationFactory) CreateHTTPServer() (server.Server, error) {
 	textNormalizer := normalizer.NewTextNormalizer()
 
 	// Create country repository
-	countryRepo, err := memory.NewCountryRepository(textNormalizer, loader)
+	countryRepo, err := memory.NewCountryRepository(textNormalizer, loader, &f.config.Matching)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create country repository: %w", err)
 	}
+	reload.LogCollisions(f.logger, countryRepo.Collisions())
+	f.reloader = reload.NewReloader(countryRepo, f.logger)
 
 	// Create country service
-	countryService := service.NewCountryService(countryRepo)
-
-	// Create country handler
-	countryHandler := handler.NewCountryHandler(countryService, f.logger)
+	f.countryService = service.NewCountryService(countryRepo)
+	return f.countryService, nil
+}
 
-	// Create and return HTTP server
-	return server.NewHTTPServer(f.config, countryHandler, countryService, f.logger), nil
+// Reloader returns the reloader for the country data loaded by CountryService
+func (f *ApplicationFactory) Reloader() (*reload.Reloader, error) {
+	if _, err := f.CountryService(); err != nil {
+		return nil, err
+	}
+	return f.reloader, nil
 }
@@ -54,6 +54,9 @@ func (api *ConfigAPI) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The admin token is never sent to the GUI, so it cannot come back from it
+	newConfig.Admin.Token = api.config.Admin.Token
+
 	// Validate the new configuration
 	if err := config.Validate(&newConfig); err != nil {
 		api.logger.Error("Invalid config", "error", err)
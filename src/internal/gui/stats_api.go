@@ -26,6 +26,7 @@ type StatsResponse struct {
 	TotalRequests        float64          `json:"total_requests"`
 	SuccessCount         float64          `json:"success_count"`
 	NotFoundCount        float64          `json:"not_found_count"`
+	AmbiguousCount       float64          `json:"ambiguous_count"`
 	ErrorCount           float64          `json:"error_count"`
 	ValidationErrorCount float64          `json:"validation_error_count"`
 	SuccessRate          float64          `json:"success_rate"`
@@ -71,6 +72,8 @@ func (api *StatsAPI) GetStats(w http.ResponseWriter, r *http.Request) {
 							stats.SuccessCount = value
 						case "not_found":
 							stats.NotFoundCount = value
+						case "ambiguous":
+							stats.AmbiguousCount = value
 						case "error":
 							stats.ErrorCount = value
 						case "validation_error":
@@ -110,10 +113,10 @@ func (api *StatsAPI) GetStats(w http.ResponseWriter, r *http.Request) {
 	stats.PopularCountries = popularCountries
 
 	// Calculate totals and rates
-	stats.TotalRequests = stats.SuccessCount + stats.NotFoundCount + stats.ErrorCount + stats.ValidationErrorCount
+	stats.TotalRequests = stats.SuccessCount + stats.NotFoundCount + stats.AmbiguousCount + stats.ErrorCount + stats.ValidationErrorCount
 	if stats.TotalRequests > 0 {
 		stats.SuccessRate = stats.SuccessCount / stats.TotalRequests
-		stats.FailureRate = (stats.NotFoundCount + stats.ErrorCount + stats.ValidationErrorCount) / stats.TotalRequests
+		stats.FailureRate = (stats.NotFoundCount + stats.AmbiguousCount + stats.ErrorCount + stats.ValidationErrorCount) / stats.TotalRequests
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"country-iso-matcher/src/internal/domain"
+	"country-iso-matcher/src/internal/service"
+)
+
+// addressRequest is the body of an address resolution request
+type addressRequest struct {
+	Address string `json:"address"`
+}
+
+// ResolveAddress finds the country of a postal address, sent as {"address": "..."} with
+// lines separated by newlines. The best country comes with the candidates considered and
+// an explanation of each.
+func (h *countryHandler) ResolveAddress(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	languages, err := parseLanguages(r, "")
+	if err != nil {
+		h.handleError(w, err, "")
+		return
+	}
+
+	var request addressRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, h.api.MaxTextSize)).Decode(&request); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			message := fmt.Sprintf("Address exceeds the maximum of %d bytes", h.api.MaxTextSize)
+			h.handleError(w, domain.NewTooLargeError(message), "")
+			return
+		}
+		h.handleError(w, domain.NewValidationError(`Request body must be a JSON object like {"address": "..."}`, ""), "")
+		return
+	}
+
+	result, err := h.service.ResolveAddress(request.Address, service.LookupOptions{Languages: languages})
+	if err != nil {
+		h.handleError(w, err, request.Address)
+		return
+	}
+
+	w.Header().Set("Vary", "Accept-Language")
+	h.writeJSON(w, result)
+}
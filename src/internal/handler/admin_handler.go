@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"country-iso-matcher/src/internal/domain"
+	"country-iso-matcher/src/internal/reload"
+)
+
+type adminHandler struct {
+	reloader *reload.Reloader
+	logger   *slog.Logger
+}
+
+func NewAdminHandler(reloader *reload.Reloader, logger *slog.Logger) AdminHandler {
+	return &adminHandler{
+		reloader: reloader,
+		logger:   logger,
+	}
+}
+
+// ReloadResponse reports a successful data reload
+type ReloadResponse struct {
+	Status     string `json:"status"`
+	Countries  int    `json:"countries"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// ReloadData rebuilds the country index from the data source. On failure the
+// previous data keeps serving and the error is returned.
+func (h *adminHandler) ReloadData(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	result, err := h.reloader.Reload(reload.TriggerAdmin)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(domain.NewInternalError("Reload failed: " + err.Error()))
+		return
+	}
+
+	json.NewEncoder(w).Encode(ReloadResponse{
+		Status:     "reloaded",
+		Countries:  result.Countries,
+		DurationMs: result.Duration.Milliseconds(),
+	})
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"country-iso-matcher/src/internal/domain"
+	"country-iso-matcher/src/internal/metrics"
+)
+
+// maxBatchQueryBytes bounds the request body size per allowed batch item
+const maxBatchQueryBytes = 1024
+
+// ConvertBatch resolves a JSON array of country queries in one request.
+// Results are returned in request order, each with its own status.
+func (h *countryHandler) ConvertBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	opts, err := parseLookupOptions(r, "")
+	if err != nil {
+		h.handleError(w, err, "")
+		return
+	}
+
+	limit := int64(h.api.MaxBatchSize) * maxBatchQueryBytes
+	body := http.MaxBytesReader(w, r.Body, limit)
+
+	var queries []string
+	if err := json.NewDecoder(body).Decode(&queries); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			message := fmt.Sprintf("Batch exceeds the maximum of %d bytes", limit)
+			h.handleError(w, domain.NewTooLargeError(message), "")
+			return
+		}
+		h.handleError(w, domain.NewValidationError("Request body must be a JSON array of strings", ""), "")
+		return
+	}
+
+	if len(queries) == 0 {
+		h.handleError(w, domain.NewValidationError("Batch must contain at least one query", ""), "")
+		return
+	}
+	if len(queries) > h.api.MaxBatchSize {
+		message := fmt.Sprintf("Batch of %d queries exceeds the maximum of %d", len(queries), h.api.MaxBatchSize)
+		h.handleError(w, domain.NewValidationError(message, ""), "")
+		return
+	}
+
+	metrics.BatchSize.WithLabelValues("convert").Observe(float64(len(queries)))
+	result := h.service.LookupBatch(queries, opts)
+
+	w.Header().Set("Vary", "Accept-Language")
+	h.writeJSON(w, result)
+}
@@ -0,0 +1,90 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"country-iso-matcher/src/internal/config"
+	"country-iso-matcher/src/internal/data"
+	"country-iso-matcher/src/internal/domain"
+	"country-iso-matcher/src/internal/handler"
+	"country-iso-matcher/src/internal/repository/memory"
+	"country-iso-matcher/src/internal/service"
+	"country-iso-matcher/src/pkg/normalizer"
+)
+
+// newTestService returns a country service over the in-memory dataset
+func newTestService(t *testing.T) service.CountryService {
+	t.Helper()
+	return newTestServiceFrom(t, data.NewMemoryLoader())
+}
+
+// newTestServiceFrom returns a country service over the countries of loader
+func newTestServiceFrom(t *testing.T, loader data.Loader) service.CountryService {
+	t.Helper()
+	matching := config.DefaultConfig().Matching
+	repo, err := memory.NewCountryRepository(normalizer.NewTextNormalizer(), loader, &matching)
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+	return service.NewCountryService(repo)
+}
+
+// newTestHandler returns a country handler over the in-memory dataset with the given limits
+func newTestHandler(t *testing.T, api config.APIConfig) handler.CountryHandler {
+	t.Helper()
+	return handler.NewCountryHandler(newTestService(t), &api, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestConvertBatch(t *testing.T) {
+	h := newTestHandler(t, config.APIConfig{MaxBatchSize: 2})
+
+	tests := []struct {
+		name           string
+		method         string
+		query          string
+		body           string
+		expectedStatus int
+		expectedError  string
+	}{
+		{name: "queries", method: http.MethodPost, body: `["Germany","xyz"]`, expectedStatus: http.StatusOK},
+		{name: "too many queries", method: http.MethodPost, body: `["a","b","c"]`, expectedStatus: http.StatusBadRequest, expectedError: "exceeds the maximum of 2"},
+		{name: "body over the size limit", method: http.MethodPost, body: `["` + strings.Repeat("a", 3000) + `"]`, expectedStatus: http.StatusRequestEntityTooLarge, expectedError: "maximum of 2048 bytes"},
+		{name: "not an array", method: http.MethodPost, body: `{"q":"Germany"}`, expectedStatus: http.StatusBadRequest, expectedError: "JSON array"},
+		{name: "empty batch", method: http.MethodPost, body: `[]`, expectedStatus: http.StatusBadRequest, expectedError: "at least one"},
+		{name: "wrong method", method: http.MethodGet, expectedStatus: http.StatusMethodNotAllowed},
+		{name: "lookup options", method: http.MethodPost, query: "?lang=de&include=meta&kind=country&likely=false", body: `["Germany","xyz"]`, expectedStatus: http.StatusOK},
+		{name: "unknown include", method: http.MethodPost, query: "?include=all", body: `["Germany"]`, expectedStatus: http.StatusBadRequest, expectedError: "Unknown include value"},
+		{name: "unknown kind", method: http.MethodPost, query: "?kind=city", body: `["Germany"]`, expectedStatus: http.StatusBadRequest, expectedError: "Unknown kind value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/v1/convert/batch"+tt.query, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			h.ConvertBatch(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body)
+			}
+			if tt.expectedStatus == http.StatusOK {
+				var response domain.BatchResponse
+				if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+					t.Fatalf("invalid response: %v", err)
+				}
+				if response.Summary.Total != 2 || response.Results[0].Status != domain.BatchStatusOK || response.Results[1].Status != "not_found" {
+					t.Errorf("unexpected response: %+v", response)
+				}
+				return
+			}
+			if tt.expectedError != "" && !strings.Contains(rec.Body.String(), tt.expectedError) {
+				t.Errorf("expected error containing %q, got %s", tt.expectedError, rec.Body)
+			}
+		})
+	}
+}
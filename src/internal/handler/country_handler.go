@@ -2,23 +2,30 @@ package handler
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/prometheus/client_golang/prometheus"
 	"log/slog"
 	"net/http"
 	"sort"
+	"strconv"
+	"strings"
 
+	"country-iso-matcher/src/internal/config"
 	"country-iso-matcher/src/internal/domain"
 	"country-iso-matcher/src/internal/service"
+	"country-iso-matcher/src/pkg/locale"
 )
 
 type countryHandler struct {
 	service service.CountryService
+	api     config.APIConfig
 	logger  *slog.Logger
 }
 
-func NewCountryHandler(service service.CountryService, logger *slog.Logger) CountryHandler {
+func NewCountryHandler(service service.CountryService, api *config.APIConfig, logger *slog.Logger) CountryHandler {
 	return &countryHandler{
 		service: service,
+		api:     *api,
 		logger:  logger,
 	}
 }
@@ -26,12 +33,19 @@ func NewCountryHandler(service service.CountryService, logger *slog.Logger) Coun
 func (h *countryHandler) ConvertCountry(w http.ResponseWriter, r *http.Request) {
 	countryName := r.URL.Query().Get("country")
 
-	result, err := h.service.LookupCountry(countryName)
+	opts, err := parseLookupOptions(r, countryName)
 	if err != nil {
 		h.handleError(w, err, countryName)
 		return
 	}
 
+	result, err := h.service.Lookup(countryName, opts)
+	if err != nil {
+		h.handleError(w, err, countryName)
+		return
+	}
+
+	w.Header().Set("Vary", "Accept-Language")
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(result); err != nil {
@@ -39,6 +53,298 @@ func (h *countryHandler) ConvertCountry(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+func (h *countryHandler) ConvertCountryV2(w http.ResponseWriter, r *http.Request) {
+	countryName := r.URL.Query().Get("country")
+
+	opts, err := parseLookupOptions(r, countryName)
+	if err != nil {
+		h.handleError(w, err, countryName)
+		return
+	}
+
+	result, err := h.service.LookupDetailed(countryName, opts)
+	if err != nil {
+		h.handleError(w, err, countryName)
+		return
+	}
+
+	w.Header().Set("Vary", "Accept-Language")
+	h.writeJSON(w, result)
+}
+
+func (h *countryHandler) SuggestCountries(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+
+	limit, err := parseLimit(r, query)
+	if err != nil {
+		h.handleError(w, err, query)
+		return
+	}
+
+	result, err := h.service.SuggestCountries(query, limit)
+	if err != nil {
+		h.handleError(w, err, query)
+		return
+	}
+
+	h.writeJSON(w, result)
+}
+
+// ConvertCode converts a country code between code systems, e.g. ?from=ioc&to=iso2&code=GER
+func (h *countryHandler) ConvertCode(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	code := params.Get("code")
+
+	languages, err := parseLanguages(r, code)
+	if err != nil {
+		h.handleError(w, err, code)
+		return
+	}
+
+	result, err := h.service.ConvertCode(params.Get("from"), params.Get("to"), code, service.LookupOptions{Languages: languages})
+	if err != nil {
+		h.handleError(w, err, code)
+		return
+	}
+
+	w.Header().Set("Vary", "Accept-Language")
+	h.writeJSON(w, result)
+}
+
+// ListCountries returns a page of the country catalog, e.g. ?region=europe&sort=name&lang=de
+func (h *countryHandler) ListCountries(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	query := service.CatalogQuery{
+		Region:   params.Get("region"),
+		Language: params.Get("language"),
+		Sort:     strings.ToLower(params.Get("sort")),
+	}
+
+	switch strings.ToLower(params.Get("order")) {
+	case "", "asc":
+	case "desc":
+		query.Descending = true
+	default:
+		h.handleError(w, domain.NewValidationError(fmt.Sprintf("Unknown order %q (must be asc or desc)", params.Get("order")), ""), "")
+		return
+	}
+
+	limit, err := parseLimit(r, "")
+	if err != nil {
+		h.handleError(w, err, "")
+		return
+	}
+	query.Limit = limit
+
+	if v := params.Get("offset"); v != "" {
+		if query.Offset, err = strconv.Atoi(v); err != nil {
+			h.handleError(w, domain.NewValidationError("offset must be a number", ""), "")
+			return
+		}
+	}
+
+	languages, err := parseLanguages(r, "")
+	if err != nil {
+		h.handleError(w, err, "")
+		return
+	}
+
+	includeMeta, err := parseInclude(r, "")
+	if err != nil {
+		h.handleError(w, err, "")
+		return
+	}
+
+	result, err := h.service.ListCountries(query, service.LookupOptions{Languages: languages, IncludeMeta: includeMeta})
+	if err != nil {
+		h.handleError(w, err, "")
+		return
+	}
+
+	w.Header().Set("Vary", "Accept-Language")
+	h.writeJSON(w, result)
+}
+
+// GetCountry returns the full record of the country with an ISO 3166-1 alpha-2, alpha-3
+// or numeric code: every name, alias and code, and its metadata
+func (h *countryHandler) GetCountry(w http.ResponseWriter, r *http.Request) {
+	code := r.PathValue("code")
+
+	languages, err := parseLanguages(r, code)
+	if err != nil {
+		h.handleError(w, err, code)
+		return
+	}
+
+	result, err := h.service.GetCountryRecord(code, service.LookupOptions{Languages: languages})
+	if err != nil {
+		h.handleError(w, err, code)
+		return
+	}
+
+	w.Header().Set("Vary", "Accept-Language")
+	h.writeJSON(w, result)
+}
+
+func (h *countryHandler) ResolveSubdivision(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	query := params.Get("q")
+
+	languages, err := parseLanguages(r, query)
+	if err != nil {
+		h.handleError(w, err, query)
+		return
+	}
+
+	result, err := h.service.LookupSubdivision(query, params.Get("country"), service.LookupOptions{Languages: languages})
+	if err != nil {
+		h.handleError(w, err, query)
+		return
+	}
+
+	w.Header().Set("Vary", "Accept-Language")
+	h.writeJSON(w, result)
+}
+
+func (h *countryHandler) ListSubdivisions(w http.ResponseWriter, r *http.Request) {
+	country := r.URL.Query().Get("country")
+
+	languages, err := parseLanguages(r, country)
+	if err != nil {
+		h.handleError(w, err, country)
+		return
+	}
+
+	result, err := h.service.ListSubdivisions(country, service.LookupOptions{Languages: languages})
+	if err != nil {
+		h.handleError(w, err, country)
+		return
+	}
+
+	w.Header().Set("Vary", "Accept-Language")
+	h.writeJSON(w, result)
+}
+
+func (h *countryHandler) AutocompleteCountries(w http.ResponseWriter, r *http.Request) {
+	prefix := r.URL.Query().Get("prefix")
+
+	limit, err := parseLimit(r, prefix)
+	if err != nil {
+		h.handleError(w, err, prefix)
+		return
+	}
+
+	languages, err := parseLanguages(r, prefix)
+	if err != nil {
+		h.handleError(w, err, prefix)
+		return
+	}
+
+	result, err := h.service.AutocompleteCountries(prefix, languages, limit)
+	if err != nil {
+		h.handleError(w, err, prefix)
+		return
+	}
+
+	w.Header().Set("Vary", "Accept-Language")
+	h.writeJSON(w, result)
+}
+
+// parseLanguages reads the preferred languages from the lang parameter or the Accept-Language header
+func parseLanguages(r *http.Request, query string) ([]string, error) {
+	languages, err := locale.Preferences(r.URL.Query().Get("lang"), r.Header.Get("Accept-Language"))
+	if err != nil {
+		return nil, domain.NewValidationError(err.Error(), query)
+	}
+	return languages, nil
+}
+
+// parseLookupOptions reads the options of a lookup: the languages (see parseLanguages) and
+// the include, kind and likely parameters
+func parseLookupOptions(r *http.Request, query string) (service.LookupOptions, error) {
+	languages, err := parseLanguages(r, query)
+	if err != nil {
+		return service.LookupOptions{}, err
+	}
+
+	includeMeta, err := parseInclude(r, query)
+	if err != nil {
+		return service.LookupOptions{}, err
+	}
+
+	kind, err := parseKind(r, query)
+	if err != nil {
+		return service.LookupOptions{}, err
+	}
+
+	likely, err := parseLikely(r, query)
+	if err != nil {
+		return service.LookupOptions{}, err
+	}
+
+	return service.LookupOptions{Languages: languages, IncludeMeta: includeMeta, Kind: kind, Likely: likely}, nil
+}
+
+// parseInclude reads the optional include parameter, a comma-separated list of extra
+// response blocks. It reports whether the metadata block was requested.
+func parseInclude(r *http.Request, query string) (bool, error) {
+	includeMeta := false
+	for _, block := range strings.Split(r.URL.Query().Get("include"), ",") {
+		switch strings.ToLower(strings.TrimSpace(block)) {
+		case "":
+		case "meta":
+			includeMeta = true
+		default:
+			return false, domain.NewValidationError(fmt.Sprintf("Unknown include value %q (must be meta)", block), query)
+		}
+	}
+	return includeMeta, nil
+}
+
+// parseKind reads the optional kind parameter: country (the default), nationality or locale
+func parseKind(r *http.Request, query string) (service.LookupKind, error) {
+	switch kind := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("kind"))); kind {
+	case "", string(service.KindCountry):
+		return service.KindCountry, nil
+	case string(service.KindNationality):
+		return service.KindNationality, nil
+	case string(service.KindLocale):
+		return service.KindLocale, nil
+	default:
+		return "", domain.NewValidationError(fmt.Sprintf("Unknown kind value %q (must be country, nationality or locale)", kind), query)
+	}
+}
+
+// parseLikely reads the optional likely parameter, which lets locale lookups fall back to
+// the likely country of a language without a region (de → DE)
+func parseLikely(r *http.Request, query string) (bool, error) {
+	v := r.URL.Query().Get("likely")
+	if v == "" {
+		return false, nil
+	}
+
+	likely, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, domain.NewValidationError("likely must be true or false", query)
+	}
+	return likely, nil
+}
+
+// parseLimit reads the optional limit query parameter; 0 means the service default
+func parseLimit(r *http.Request, query string) (int, error) {
+	v := r.URL.Query().Get("limit")
+	if v == "" {
+		return 0, nil
+	}
+
+	limit, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, domain.NewValidationError("limit must be a number", query)
+	}
+	return limit, nil
+}
+
 func (h *countryHandler) Health(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -48,6 +354,14 @@ func (h *countryHandler) Health(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (h *countryHandler) writeJSON(w http.ResponseWriter, result any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
 func (h *countryHandler) handleError(w http.ResponseWriter, err error, query string) {
 	appErr, ok := err.(*domain.AppError)
 	if !ok {
@@ -64,6 +378,7 @@ type StatsResponse struct {
 	TotalRequests        float64          `json:"total_requests"`
 	SuccessCount         float64          `json:"success_count"`
 	NotFoundCount        float64          `json:"not_found_count"`
+	AmbiguousCount       float64          `json:"ambiguous_count"`
 	ErrorCount           float64          `json:"error_count"`
 	ValidationErrorCount float64          `json:"validation_error_count"`
 	SuccessRate          float64          `json:"success_rate"`
@@ -103,6 +418,8 @@ func (h *countryHandler) GetStats(w http.ResponseWriter, r *http.Request) {
 							stats.SuccessCount = value
 						case "not_found":
 							stats.NotFoundCount = value
+						case "ambiguous":
+							stats.AmbiguousCount = value
 						case "error":
 							stats.ErrorCount = value
 						case "validation_error":
@@ -142,10 +459,10 @@ func (h *countryHandler) GetStats(w http.ResponseWriter, r *http.Request) {
 	stats.PopularCountries = popularCountries
 
 	// Calculate totals and rates
-	stats.TotalRequests = stats.SuccessCount + stats.NotFoundCount + stats.ErrorCount + stats.ValidationErrorCount
+	stats.TotalRequests = stats.SuccessCount + stats.NotFoundCount + stats.AmbiguousCount + stats.ErrorCount + stats.ValidationErrorCount
 	if stats.TotalRequests > 0 {
 		stats.SuccessRate = stats.SuccessCount / stats.TotalRequests
-		stats.FailureRate = (stats.NotFoundCount + stats.ErrorCount + stats.ValidationErrorCount) / stats.TotalRequests
+		stats.FailureRate = (stats.NotFoundCount + stats.AmbiguousCount + stats.ErrorCount + stats.ValidationErrorCount) / stats.TotalRequests
 	}
 
 	w.Header().Set("Content-Type", "application/json")
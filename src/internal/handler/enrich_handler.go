@@ -0,0 +1,276 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"country-iso-matcher/src/internal/data"
+	"country-iso-matcher/src/internal/domain"
+	"country-iso-matcher/src/internal/metrics"
+	"country-iso-matcher/src/internal/service"
+)
+
+const (
+	// enrichStatusColumn is always appended to enriched rows
+	enrichStatusColumn = "match_status"
+	// enrichFlushRows is how many rows are written between flushes to the client
+	enrichFlushRows = 500
+	// defaultEnrichColumns is used when the add parameter is missing
+	defaultEnrichColumns = "iso2,iso3,name"
+)
+
+// enrichColumns are the values accepted in the add parameter
+var enrichColumns = map[string]func(*domain.CountryResponse) string{
+	"iso2":           func(r *domain.CountryResponse) string { return r.ISO2Code },
+	"iso3":           func(r *domain.CountryResponse) string { return r.ISO3Code },
+	"numeric":        func(r *domain.CountryResponse) string { return r.NumericCode },
+	"name":           func(r *domain.CountryResponse) string { return r.OfficialName },
+	"localized_name": func(r *domain.CountryResponse) string { return r.LocalizedName },
+	"match_type":     func(r *domain.CountryResponse) string { return r.MatchType },
+}
+
+// EnrichCSV streams an uploaded CSV through the matcher and returns it with ISO columns
+// and a match status appended to every row. The file is read and written row by row,
+// so its size is not limited by memory. The CSV can be sent as the raw request body
+// or as the "file" field of a multipart form.
+func (h *countryHandler) EnrichCSV(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	column := strings.TrimSpace(query.Get("column"))
+	if column == "" {
+		h.handleError(w, domain.NewValidationError("Query parameter column is required", ""), "")
+		return
+	}
+
+	columns, err := parseEnrichColumns(query.Get("add"))
+	if err != nil {
+		h.handleError(w, err, column)
+		return
+	}
+
+	delimiter, err := parseDelimiter(query.Get("delimiter"))
+	if err != nil {
+		h.handleError(w, err, column)
+		return
+	}
+
+	opts, err := parseLookupOptions(r, column)
+	if err != nil {
+		h.handleError(w, err, column)
+		return
+	}
+
+	controller := http.NewResponseController(w)
+	// HTTP/1.x stops reading the body once the response starts unless full duplex is enabled
+	if err := controller.EnableFullDuplex(); err != nil && r.ProtoMajor == 1 {
+		h.logger.Warn("failed to enable full duplex for CSV enrichment", "error", err)
+	}
+
+	input, err := csvInput(w, r, h.api.MaxUploadSize)
+	if err != nil {
+		h.handleError(w, err, column)
+		return
+	}
+
+	reader := csv.NewReader(input)
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1 // Allow rows of different length
+	reader.LazyQuotes = true
+
+	first, err := reader.Read()
+	if err == io.EOF {
+		h.handleError(w, domain.NewValidationError("CSV is empty", column), column)
+		return
+	}
+	if err != nil {
+		h.handleError(w, csvReadError(err, h.api.MaxUploadSize, column), column)
+		return
+	}
+
+	columnIndex, hasHeader, err := findEnrichColumn(first, column, query.Get("header"))
+	if err != nil {
+		h.handleError(w, err, column)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="enriched.csv"`)
+	w.Header().Set("Vary", "Accept-Language")
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	writer.Comma = delimiter
+
+	rows := 0
+	if hasHeader {
+		writer.Write(append(first, append(columns, enrichStatusColumn)...))
+	} else {
+		writer.Write(h.enrichRecord(first, columnIndex, columns, opts))
+		rows++
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// The status line is already sent, so the best we can do is stop and log
+			h.logger.Error("failed to read CSV row during enrichment", "error", err, "rows", rows)
+			break
+		}
+
+		writer.Write(h.enrichRecord(record, columnIndex, columns, opts))
+		rows++
+
+		if rows%enrichFlushRows == 0 {
+			writer.Flush()
+			controller.Flush()
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		h.logger.Error("failed to write enriched CSV", "error", err, "rows", rows)
+	}
+	metrics.BatchSize.WithLabelValues("enrich_csv").Observe(float64(rows))
+}
+
+// enrichRecord looks up the country column of one row and appends the requested columns
+func (h *countryHandler) enrichRecord(record []string, columnIndex int, columns []string, opts service.LookupOptions) []string {
+	values := make([]string, len(columns)+1)
+
+	var query string
+	if columnIndex < len(record) {
+		query = record[columnIndex]
+	}
+
+	item := h.service.LookupItem(0, query, opts)
+	if item.Result != nil {
+		for i, column := range columns {
+			values[i] = enrichColumns[column](item.Result)
+		}
+	}
+	values[len(columns)] = item.Status
+	return append(record, values...)
+}
+
+// parseEnrichColumns validates the comma separated add parameter
+func parseEnrichColumns(add string) ([]string, error) {
+	if strings.TrimSpace(add) == "" {
+		add = defaultEnrichColumns
+	}
+
+	var columns []string
+	for _, column := range strings.Split(add, ",") {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if column == "" {
+			continue
+		}
+		if _, ok := enrichColumns[column]; !ok {
+			return nil, domain.NewValidationError(
+				fmt.Sprintf("Unknown column %q in add (must be iso2, iso3, numeric, name, localized_name or match_type)", column), add)
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// parseDelimiter reads the optional delimiter parameter; "tab" selects tab-separated input
+func parseDelimiter(delimiter string) (rune, error) {
+	switch delimiter {
+	case "":
+		return ',', nil
+	case "tab", `\t`, "\t":
+		return '\t', nil
+	}
+
+	runes := []rune(delimiter)
+	if len(runes) != 1 || runes[0] == '"' || runes[0] == '\r' || runes[0] == '\n' {
+		return 0, domain.NewValidationError("delimiter must be a single character or 'tab'", delimiter)
+	}
+	return runes[0], nil
+}
+
+// findEnrichColumn locates the country column. A column name matching the first row
+// (case-insensitive) means the file has a header; otherwise column must be a 1-based
+// column number, and the header is detected from that column's cell (see data.IsHeaderCell)
+// unless the header parameter says otherwise.
+func findEnrichColumn(first []string, column, header string) (int, bool, error) {
+	for i, field := range first {
+		if strings.EqualFold(strings.TrimSpace(field), column) {
+			return i, true, nil
+		}
+	}
+
+	number, err := strconv.Atoi(column)
+	if err != nil {
+		return 0, false, domain.NewValidationError(fmt.Sprintf("Column %q not found in CSV header", column), column)
+	}
+	if number < 1 {
+		return 0, false, domain.NewValidationError("Column number must be 1 or greater", column)
+	}
+	index := number - 1
+
+	switch strings.ToLower(header) {
+	case "":
+		return index, index < len(first) && data.IsHeaderCell(first[index]), nil
+	case "true", "1", "yes":
+		return index, true, nil
+	case "false", "0", "no":
+		return index, false, nil
+	}
+	return 0, false, domain.NewValidationError("header must be true or false", header)
+}
+
+// csvInput returns the uploaded CSV, either the raw body or the "file" part of a multipart form
+func csvInput(w http.ResponseWriter, r *http.Request, maxSize int64) (io.Reader, error) {
+	body := http.MaxBytesReader(w, r.Body, maxSize)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		return body, nil
+	}
+
+	r.Body = body
+	multipartReader, err := r.MultipartReader()
+	if err != nil {
+		return nil, domain.NewValidationError("Invalid multipart upload", "")
+	}
+
+	for {
+		part, err := multipartReader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, domain.NewValidationError("Multipart upload has no file field", "")
+		}
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return nil, domain.NewTooLargeError(fmt.Sprintf("CSV exceeds the maximum of %d bytes", maxSize))
+			}
+			return nil, domain.NewValidationError("Invalid multipart upload", "")
+		}
+		if part.FormName() == "file" {
+			return part, nil
+		}
+	}
+}
+
+// csvReadError reports a failure to read the uploaded CSV, answering uploads over maxSize with 413
+func csvReadError(err error, maxSize int64, column string) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return domain.NewTooLargeError(fmt.Sprintf("CSV exceeds the maximum of %d bytes", maxSize))
+	}
+	return domain.NewValidationError("Invalid CSV: "+err.Error(), column)
+}
@@ -0,0 +1,128 @@
+package handler_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"country-iso-matcher/src/internal/config"
+)
+
+func TestEnrichCSV(t *testing.T) {
+	h := newTestHandler(t, config.APIConfig{MaxUploadSize: 64})
+
+	tests := []struct {
+		name           string
+		params         string
+		body           string
+		expectedStatus int
+		expected       string
+	}{
+		{
+			name:           "header by name",
+			params:         "column=Country",
+			body:           "id,Country\n1,Germany\n2,Atlantis\n",
+			expectedStatus: http.StatusOK,
+			expected:       "id,Country,iso2,iso3,name,match_status\n1,Germany,DE,DEU,Germany,ok\n2,Atlantis,,,,not_found\n",
+		},
+		{
+			name:           "header detected by column number",
+			params:         "column=2&add=iso2",
+			body:           "id,country\n1,France\n",
+			expectedStatus: http.StatusOK,
+			expected:       "id,country,iso2,match_status\n1,France,FR,ok\n",
+		},
+		{
+			name:           "no header",
+			params:         "column=1&add=iso3,match_type",
+			body:           "Romania\nDeutschland\n",
+			expectedStatus: http.StatusOK,
+			expected:       "Romania,ROU,exact,ok\nDeutschland,DEU,alias,ok\n",
+		},
+		{
+			name:           "header forced off",
+			params:         "column=1&add=iso2&header=false",
+			body:           "country\nSpain\n",
+			expectedStatus: http.StatusOK,
+			expected:       "country,,not_found\nSpain,ES,ok\n",
+		},
+		{
+			name:           "tab delimiter",
+			params:         "column=name&add=iso2&delimiter=tab",
+			body:           "name\tid\nItaly\t7\n",
+			expectedStatus: http.StatusOK,
+			expected:       "name\tid\tiso2\tmatch_status\nItaly\t7\tIT\tok\n",
+		},
+		{
+			name:           "unknown header column",
+			params:         "column=nation",
+			body:           "id,country\n1,France\n",
+			expectedStatus: http.StatusBadRequest,
+			expected:       `Column \"nation\" not found`,
+		},
+		{
+			name:           "unknown output column",
+			params:         "column=country&add=iso2,flag",
+			body:           "country\nFrance\n",
+			expectedStatus: http.StatusBadRequest,
+			expected:       `Unknown column \"flag\"`,
+		},
+		{
+			name:           "missing column",
+			body:           "country\nFrance\n",
+			expectedStatus: http.StatusBadRequest,
+			expected:       "column is required",
+		},
+		{
+			name:           "over the size limit",
+			params:         "column=1",
+			body:           strings.Repeat("x", 100) + "\nFrance\n",
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expected:       "maximum of 64 bytes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/enrich/csv?"+tt.params, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			h.EnrichCSV(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body)
+			}
+			if tt.expectedStatus == http.StatusOK {
+				if rec.Body.String() != tt.expected {
+					t.Errorf("expected\n%q\ngot\n%q", tt.expected, rec.Body.String())
+				}
+				return
+			}
+			if !strings.Contains(rec.Body.String(), tt.expected) {
+				t.Errorf("expected error containing %q, got %s", tt.expected, rec.Body)
+			}
+		})
+	}
+}
+
+func TestEnrichCSV_Multipart(t *testing.T) {
+	h := newTestHandler(t, config.APIConfig{MaxUploadSize: 1024})
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	form.WriteField("note", "ignored")
+	file, _ := form.CreateFormFile("file", "customers.csv")
+	file.Write([]byte("country\nPoland\n"))
+	form.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/enrich/csv?column=country&add=iso2", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	rec := httptest.NewRecorder()
+	h.EnrichCSV(rec, req)
+
+	if expected := "country,iso2,match_status\nPoland,PL,ok\n"; rec.Code != http.StatusOK || rec.Body.String() != expected {
+		t.Errorf("expected %q, got %d %q", expected, rec.Code, rec.Body.String())
+	}
+}
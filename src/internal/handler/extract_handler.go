@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"country-iso-matcher/src/internal/domain"
+	"country-iso-matcher/src/internal/service"
+)
+
+// extractRequest is the body of an extraction request
+type extractRequest struct {
+	Text string `json:"text"`
+}
+
+// ExtractCountries finds the countries mentioned in a free text, sent as {"text": "..."}.
+// Every mention is returned with its character offsets and confidence.
+func (h *countryHandler) ExtractCountries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	languages, err := parseLanguages(r, "")
+	if err != nil {
+		h.handleError(w, err, "")
+		return
+	}
+
+	var request extractRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, h.api.MaxTextSize)).Decode(&request); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			message := fmt.Sprintf("Text exceeds the maximum of %d bytes", h.api.MaxTextSize)
+			h.handleError(w, domain.NewTooLargeError(message), "")
+			return
+		}
+		h.handleError(w, domain.NewValidationError(`Request body must be a JSON object like {"text": "..."}`, ""), "")
+		return
+	}
+
+	result, err := h.service.ExtractCountries(request.Text, service.LookupOptions{Languages: languages})
+	if err != nil {
+		h.handleError(w, err, "")
+		return
+	}
+
+	w.Header().Set("Vary", "Accept-Language")
+	h.writeJSON(w, result)
+}
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"country-iso-matcher/src/internal/domain"
+	"country-iso-matcher/src/internal/metrics"
+	"country-iso-matcher/src/internal/service"
+	pb "country-iso-matcher/src/pkg/pb/countrymatcher/v1"
+)
+
+// countryGRPCHandler exposes the country service over gRPC
+type countryGRPCHandler struct {
+	pb.UnimplementedCountryMatcherServer
+	service service.CountryService
+	logger  *slog.Logger
+}
+
+func NewCountryGRPCHandler(service service.CountryService, logger *slog.Logger) pb.CountryMatcherServer {
+	return &countryGRPCHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *countryGRPCHandler) Lookup(ctx context.Context, req *pb.LookupRequest) (*pb.LookupResponse, error) {
+	result, err := h.service.Lookup(req.GetQuery(), service.LookupOptions{Languages: req.GetLanguages()})
+	if err != nil {
+		return nil, h.grpcError(err, req.GetQuery())
+	}
+	return lookupResponse(result), nil
+}
+
+// BatchLookup answers every request on the stream in order. Lookup failures are
+// reported per item, so only transport errors end the stream.
+func (h *countryGRPCHandler) BatchLookup(stream pb.CountryMatcher_BatchLookupServer) error {
+	index := 0
+	defer func() {
+		metrics.BatchSize.WithLabelValues("grpc_batch_lookup").Observe(float64(index))
+	}()
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		item := h.service.LookupItem(index, req.GetQuery(), service.LookupOptions{Languages: req.GetLanguages()})
+		index++
+
+		response := &pb.BatchLookupResponse{
+			Index:  int32(item.Index),
+			Query:  item.Query,
+			Status: item.Status,
+		}
+		if item.Result != nil {
+			response.Result = lookupResponse(item.Result)
+		}
+		if item.Error != nil {
+			response.Error = item.Error.Message
+			response.Candidates = candidates(item.Error.Candidates)
+		}
+
+		if err := stream.Send(response); err != nil {
+			return err
+		}
+	}
+}
+
+func (h *countryGRPCHandler) GetCountry(ctx context.Context, req *pb.GetCountryRequest) (*pb.LookupResponse, error) {
+	result, err := h.service.GetCountry(req.GetCode(), service.LookupOptions{Languages: req.GetLanguages()})
+	if err != nil {
+		return nil, h.grpcError(err, req.GetCode())
+	}
+	return lookupResponse(result), nil
+}
+
+// grpcError converts service errors to gRPC status errors, mirroring handleError
+func (h *countryGRPCHandler) grpcError(err error, query string) error {
+	appErr, ok := err.(*domain.AppError)
+	if !ok {
+		h.logger.Error("unexpected error", "error", err, "query", query)
+		return status.Error(codes.Internal, "Internal server error")
+	}
+
+	switch appErr.Code {
+	case http.StatusBadRequest:
+		return status.Error(codes.InvalidArgument, appErr.Message)
+	case http.StatusNotFound:
+		return status.Error(codes.NotFound, appErr.Message)
+	case http.StatusConflict:
+		// The candidates go along as a status detail, as the HTTP error lists them
+		st, detailErr := status.New(codes.FailedPrecondition, appErr.Message).WithDetails(&pb.AmbiguousMatch{
+			Query:      appErr.Query,
+			Candidates: candidates(appErr.Candidates),
+		})
+		if detailErr != nil {
+			h.logger.Error("failed to attach candidates", "error", detailErr, "query", query)
+			return status.Error(codes.FailedPrecondition, appErr.Message)
+		}
+		return st.Err()
+	default:
+		return status.Error(codes.Internal, appErr.Message)
+	}
+}
+
+// candidates converts the candidates of an ambiguous query
+func candidates(found []domain.CountryCandidate) []*pb.Country {
+	var countries []*pb.Country
+	for _, candidate := range found {
+		countries = append(countries, &pb.Country{
+			OfficialName: candidate.OfficialName,
+			Iso2Code:     candidate.ISO2Code,
+			Iso3Code:     candidate.ISO3Code,
+		})
+	}
+	return countries
+}
+
+func lookupResponse(result *domain.CountryResponse) *pb.LookupResponse {
+	response := &pb.LookupResponse{
+		Query: result.Query,
+		Country: &pb.Country{
+			OfficialName:  result.OfficialName,
+			Iso2Code:      result.ISO2Code,
+			Iso3Code:      result.ISO3Code,
+			NumericCode:   result.NumericCode,
+			LocalizedName: result.LocalizedName,
+			Language:      result.Language,
+		},
+		MatchType:   result.MatchType,
+		MatchedName: result.MatchedName,
+		Distance:    int32(result.Distance),
+	}
+
+	if h := result.Historical; h != nil {
+		response.Historical = &pb.HistoricalCountry{
+			Code:         h.Code,
+			OfficialName: h.OfficialName,
+			Iso2Code:     h.ISO2Code,
+			Iso3Code:     h.ISO3Code,
+			NumericCode:  h.NumericCode,
+			ValidFrom:    h.ValidFrom,
+			ValidTo:      h.ValidTo,
+		}
+		for _, successor := range h.Successors {
+			response.Historical.Successors = append(response.Historical.Successors, &pb.Country{
+				OfficialName: successor.OfficialName,
+				Iso2Code:     successor.ISO2Code,
+				Iso3Code:     successor.ISO3Code,
+			})
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,193 @@
+package handler_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"country-iso-matcher/src/internal/domain"
+	"country-iso-matcher/src/internal/handler"
+	"country-iso-matcher/src/internal/service"
+	pb "country-iso-matcher/src/pkg/pb/countrymatcher/v1"
+)
+
+// stubLoader serves a fixed set of countries
+type stubLoader struct {
+	countries []domain.Country
+}
+
+func (l *stubLoader) LoadCountries() ([]domain.Country, error) { return l.countries, nil }
+
+func (l *stubLoader) LoadAliases() (map[string][]string, error) { return nil, nil }
+
+// newTestGRPCClient serves the gRPC handler for countryService over an in-memory connection
+func newTestGRPCClient(t *testing.T, countryService service.CountryService) pb.CountryMatcherClient {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	pb.RegisterCountryMatcherServer(server, handler.NewCountryGRPCHandler(countryService, logger))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewCountryMatcherClient(conn)
+}
+
+func TestCountryGRPCHandler_Lookup(t *testing.T) {
+	client := newTestGRPCClient(t, newTestService(t))
+
+	tests := []struct {
+		name          string
+		request       *pb.LookupRequest
+		expectedCode  string
+		expectedName  string
+		expectedError codes.Code
+	}{
+		{name: "name", request: &pb.LookupRequest{Query: "Germany"}, expectedCode: "DE"},
+		{name: "localized with fallback", request: &pb.LookupRequest{Query: "Romania", Languages: []string{"de"}}, expectedCode: "RO", expectedName: "Romania"},
+		{name: "not found", request: &pb.LookupRequest{Query: "Atlantis"}, expectedError: codes.NotFound},
+		{name: "empty query", request: &pb.LookupRequest{Query: " "}, expectedError: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := client.Lookup(context.Background(), tt.request)
+			if tt.expectedError != codes.OK {
+				if status.Code(err) != tt.expectedError {
+					t.Fatalf("expected %v, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if response.GetCountry().GetIso2Code() != tt.expectedCode || response.GetCountry().GetLocalizedName() != tt.expectedName {
+				t.Errorf("expected %s (%q), got %+v", tt.expectedCode, tt.expectedName, response.GetCountry())
+			}
+		})
+	}
+}
+
+func TestCountryGRPCHandler_LookupAmbiguous(t *testing.T) {
+	client := newTestGRPCClient(t, newTestServiceFrom(t, &stubLoader{
+		countries: []domain.Country{
+			{ISO2: "CG", ISO3: "COG", Names: map[string]string{"en": "Congo", "fr": "Congo"}},
+			{ISO2: "CD", ISO3: "COD", Names: map[string]string{"en": "Democratic Republic of the Congo", "fr": "Congo"}},
+		},
+	}))
+
+	_, err := client.Lookup(context.Background(), &pb.LookupRequest{Query: "Congo"})
+	st := status.Convert(err)
+	if st.Code() != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+
+	var found []string
+	for _, detail := range st.Details() {
+		if match, ok := detail.(*pb.AmbiguousMatch); ok {
+			for _, candidate := range match.GetCandidates() {
+				found = append(found, candidate.GetIso2Code()+"/"+candidate.GetIso3Code())
+			}
+		}
+	}
+	if got := strings.Join(found, ","); got != "CD/COD,CG/COG" {
+		t.Errorf("expected the candidates as status details, got %q", got)
+	}
+
+	// Batch items carry the candidates too
+	stream, err := client.BatchLookup(context.Background())
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	if err := stream.Send(&pb.LookupRequest{Query: "Congo"}); err != nil {
+		t.Fatalf("failed to send: %v", err)
+	}
+	response, err := stream.Recv()
+	if err != nil || response.GetStatus() != "ambiguous" || len(response.GetCandidates()) != 2 {
+		t.Errorf("expected an ambiguous item with 2 candidates, got %+v, %v", response, err)
+	}
+	stream.CloseSend()
+}
+
+func TestCountryGRPCHandler_GetCountry(t *testing.T) {
+	client := newTestGRPCClient(t, newTestService(t))
+
+	response, err := client.GetCountry(context.Background(), &pb.GetCountryRequest{Code: "deu"})
+	if err != nil || response.GetCountry().GetIso2Code() != "DE" {
+		t.Errorf("expected DE, got %+v, %v", response, err)
+	}
+
+	if _, err := client.GetCountry(context.Background(), &pb.GetCountryRequest{Code: "TOOLONG"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+	if _, err := client.GetCountry(context.Background(), &pb.GetCountryRequest{Code: "QQ"}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
+
+func TestCountryGRPCHandler_BatchLookup(t *testing.T) {
+	client := newTestGRPCClient(t, newTestService(t))
+
+	stream, err := client.BatchLookup(context.Background())
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+
+	queries := []string{"France", "Atlantis", "", "Deutschland"}
+	for _, query := range queries {
+		if err := stream.Send(&pb.LookupRequest{Query: query}); err != nil {
+			t.Fatalf("failed to send %q: %v", query, err)
+		}
+	}
+	stream.CloseSend()
+
+	expected := []struct {
+		status string
+		code   string
+	}{
+		{status: "ok", code: "FR"},
+		{status: "not_found"},
+		{status: "validation"},
+		{status: "ok", code: "DE"},
+	}
+
+	for i, want := range expected {
+		response, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("item %d: unexpected error: %v", i, err)
+		}
+		if int(response.GetIndex()) != i || response.GetQuery() != queries[i] || response.GetStatus() != want.status {
+			t.Errorf("item %d: expected %q with status %s, got %+v", i, queries[i], want.status, response)
+		}
+		if response.GetResult().GetCountry().GetIso2Code() != want.code {
+			t.Errorf("item %d: expected country %q, got %+v", i, want.code, response.GetResult())
+		}
+		if (want.status != "ok") != (response.GetError() != "") {
+			t.Errorf("item %d: expected an error message only for failures, got %q", i, response.GetError())
+		}
+	}
+
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Errorf("expected the stream to end, got %v", err)
+	}
+}
@@ -6,6 +6,24 @@ import (
 
 type CountryHandler interface {
 	ConvertCountry(w http.ResponseWriter, r *http.Request)
+	ConvertCountryV2(w http.ResponseWriter, r *http.Request)
+	ConvertBatch(w http.ResponseWriter, r *http.Request)
+	ConvertStream(w http.ResponseWriter, r *http.Request)
+	EnrichCSV(w http.ResponseWriter, r *http.Request)
+	ResolveAddress(w http.ResponseWriter, r *http.Request)
+	ResolveLocale(w http.ResponseWriter, r *http.Request)
+	ExtractCountries(w http.ResponseWriter, r *http.Request)
+	SuggestCountries(w http.ResponseWriter, r *http.Request)
+	AutocompleteCountries(w http.ResponseWriter, r *http.Request)
+	ConvertCode(w http.ResponseWriter, r *http.Request)
+	ListCountries(w http.ResponseWriter, r *http.Request)
+	GetCountry(w http.ResponseWriter, r *http.Request)
+	ResolveSubdivision(w http.ResponseWriter, r *http.Request)
+	ListSubdivisions(w http.ResponseWriter, r *http.Request)
 	Health(w http.ResponseWriter, r *http.Request)
 	GetStats(w http.ResponseWriter, r *http.Request)
 }
+
+type AdminHandler interface {
+	ReloadData(w http.ResponseWriter, r *http.Request)
+}
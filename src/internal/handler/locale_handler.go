@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+
+	"country-iso-matcher/src/internal/service"
+)
+
+// ResolveLocale finds the country of a locale identifier, e.g. ?locale=pt_BR.UTF-8 or
+// ?locale=zh-Hant-TW. Locales naming a UN M49 area (es-419) return the area; with
+// likely=true, locales without a region return the likely country of their language.
+func (h *countryHandler) ResolveLocale(w http.ResponseWriter, r *http.Request) {
+	identifier := r.URL.Query().Get("locale")
+
+	languages, err := parseLanguages(r, identifier)
+	if err != nil {
+		h.handleError(w, err, identifier)
+		return
+	}
+
+	likely, err := parseLikely(r, identifier)
+	if err != nil {
+		h.handleError(w, err, identifier)
+		return
+	}
+
+	result, err := h.service.ResolveLocale(identifier, service.LookupOptions{Languages: languages, Likely: likely})
+	if err != nil {
+		h.handleError(w, err, identifier)
+		return
+	}
+
+	w.Header().Set("Vary", "Accept-Language")
+	h.writeJSON(w, result)
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"country-iso-matcher/src/internal/domain"
+)
+
+// BearerToken only lets requests through that send "Authorization: Bearer <token>";
+// others are answered with 401
+func BearerToken(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sent, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || token == "" || subtle.ConstantTimeCompare([]byte(sent), []byte(token)) != 1 {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				w.WriteHeader(http.StatusUnauthorized)
+				json.NewEncoder(w).Encode(&domain.AppError{Code: http.StatusUnauthorized, Message: "Unauthorized"})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
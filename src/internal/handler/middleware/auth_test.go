@@ -0,0 +1,43 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"country-iso-matcher/src/internal/handler/middleware"
+)
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		name           string
+		token          string
+		authorization  string
+		expectedStatus int
+	}{
+		{name: "valid token", token: "secret", authorization: "Bearer secret", expectedStatus: http.StatusOK},
+		{name: "wrong token", token: "secret", authorization: "Bearer guess", expectedStatus: http.StatusUnauthorized},
+		{name: "missing header", token: "secret", expectedStatus: http.StatusUnauthorized},
+		{name: "other scheme", token: "secret", authorization: "Basic secret", expectedStatus: http.StatusUnauthorized},
+		{name: "no token configured", token: "", authorization: "Bearer ", expectedStatus: http.StatusUnauthorized},
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/reload", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			middleware.BearerToken(tt.token)(next).ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"country-iso-matcher/src/internal/metrics"
+)
+
+// GRPCUnaryInterceptor applies the HTTP middleware conventions to unary gRPC calls:
+// panic recovery, Prometheus request metrics and a "request completed" log line.
+func GRPCUnaryInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		start := time.Now()
+		metrics.GRPCActiveCalls.Inc()
+		defer metrics.GRPCActiveCalls.Dec()
+
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoverGRPC(logger, info.FullMethod, r)
+			}
+			observeGRPC(logger, info.FullMethod, start, err)
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// GRPCStreamInterceptor is the streaming counterpart of GRPCUnaryInterceptor
+func GRPCStreamInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		start := time.Now()
+		metrics.GRPCActiveCalls.Inc()
+		defer metrics.GRPCActiveCalls.Dec()
+
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoverGRPC(logger, info.FullMethod, r)
+			}
+			observeGRPC(logger, info.FullMethod, start, err)
+		}()
+
+		return handler(srv, stream)
+	}
+}
+
+func recoverGRPC(logger *slog.Logger, method string, recovered any) error {
+	logger.Error("panic recovered",
+		"error", recovered,
+		"method", method,
+		"stack", string(debug.Stack()),
+	)
+	return status.Error(codes.Internal, "Internal server error")
+}
+
+func observeGRPC(logger *slog.Logger, method string, start time.Time, err error) {
+	duration := time.Since(start)
+	code := status.Code(err).String()
+
+	metrics.GRPCRequestsTotal.WithLabelValues(method, code).Inc()
+	metrics.GRPCRequestDuration.WithLabelValues(method, code).Observe(duration.Seconds())
+
+	logger.Info("request completed",
+		"method", method,
+		"code", code,
+		"duration_ms", duration.Milliseconds(),
+	)
+}
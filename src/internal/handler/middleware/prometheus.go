@@ -3,6 +3,7 @@ package middleware
 import (
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"country-iso-matcher/src/internal/metrics"
@@ -25,6 +26,11 @@ func (prw *prometheusResponseWriter) Write(b []byte) (int, error) {
 	return n, err
 }
 
+// Unwrap exposes the underlying writer to http.ResponseController (flushing, deadlines)
+func (prw *prometheusResponseWriter) Unwrap() http.ResponseWriter {
+	return prw.ResponseWriter
+}
+
 // PrometheusMetrics middleware collects HTTP metrics for Prometheus
 func PrometheusMetrics(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -65,13 +71,44 @@ func getEndpointLabel(path string) string {
 	switch path {
 	case "/api/convert":
 		return "convert"
+	case "/api/v2/convert":
+		return "convert_v2"
+	case "/api/v1/convert/batch":
+		return "convert_batch"
+	case "/api/v1/convert/stream":
+		return "convert_stream"
+	case "/api/v1/enrich/csv":
+		return "enrich_csv"
+	case "/api/v1/admin/reload":
+		return "admin_reload"
+	case "/api/v1/address":
+		return "address"
+	case "/api/v1/locale":
+		return "locale"
+	case "/api/v1/extract":
+		return "extract"
+	case "/api/v1/suggest":
+		return "suggest"
+	case "/api/v1/autocomplete":
+		return "autocomplete"
+	case "/api/v1/codes/convert":
+		return "codes_convert"
+	case "/api/v1/countries":
+		return "countries_list"
+	case "/api/v1/subdivisions":
+		return "subdivisions"
+	case "/api/v1/subdivisions/resolve":
+		return "subdivisions_resolve"
 	case "/health":
 		return "health"
 	case "/metrics":
 		return "metrics"
 	case "/":
 		return "root"
-	default:
-		return "other"
 	}
+
+	if strings.HasPrefix(path, "/api/v1/countries/") {
+		return "countries"
+	}
+	return "other"
 }
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// StreamTimeout replaces the server-wide read and write deadlines for streaming routes,
+// which keep reading the request body and writing results for as long as the client sends.
+// A timeout of 0 removes the deadlines.
+func StreamTimeout(timeout time.Duration, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var deadline time.Time
+			if timeout > 0 {
+				deadline = time.Now().Add(timeout)
+			}
+
+			controller := http.NewResponseController(w)
+			if err := controller.SetReadDeadline(deadline); err != nil {
+				logger.Warn("failed to set stream read deadline", "path", r.URL.Path, "error", err)
+			}
+			if err := controller.SetWriteDeadline(deadline); err != nil {
+				logger.Warn("failed to set stream write deadline", "path", r.URL.Path, "error", err)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"country-iso-matcher/src/internal/domain"
+	"country-iso-matcher/src/internal/metrics"
+)
+
+// maxStreamLineBytes bounds a single input line of a stream
+const maxStreamLineBytes = 64 * 1024
+
+// streamQuery is the object form of an NDJSON input line
+type streamQuery struct {
+	Query string `json:"query"`
+}
+
+// ConvertStream resolves newline-delimited queries as they arrive and writes one NDJSON
+// result per input line, flushing after each, so clients can pipe data of any size
+// through the service. Lines may be JSON strings, {"query": "..."} objects or plain text;
+// blank lines are skipped.
+func (h *countryHandler) ConvertStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	opts, err := parseLookupOptions(r, "")
+	if err != nil {
+		h.handleError(w, err, "")
+		return
+	}
+
+	controller := http.NewResponseController(w)
+	// HTTP/1.x stops reading the body once the response starts unless full duplex is enabled
+	if err := controller.EnableFullDuplex(); err != nil && r.ProtoMajor == 1 {
+		h.logger.Warn("failed to enable full duplex for stream", "error", err)
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Vary", "Accept-Language")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	// The status line goes out with the first result: writing it before reading the body
+	// would make the server drop bodies sent with "Expect: 100-continue"
+
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(make([]byte, 0, 4096), maxStreamLineBytes)
+
+	encoder := json.NewEncoder(w)
+
+	index := 0
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var item domain.BatchItem
+		if query, ok := parseStreamLine(line); ok {
+			item = h.service.LookupItem(index, query, opts)
+		} else {
+			appErr := domain.NewValidationError("Line must be a JSON string, a {\"query\": ...} object or plain text", string(line))
+			item = domain.BatchItem{Index: index, Query: string(line), Status: domain.ErrorStatus(appErr), Error: appErr}
+		}
+		index++
+
+		if err := encoder.Encode(item); err != nil {
+			h.logger.Warn("stream client went away", "error", err, "lines", index)
+			break
+		}
+		controller.Flush()
+	}
+
+	if err := scanner.Err(); err != nil {
+		// The status line is already sent, so the best we can do is stop and log
+		h.logger.Error("failed to read stream input", "error", err, "lines", index)
+	}
+	metrics.BatchSize.WithLabelValues("convert_stream").Observe(float64(index))
+}
+
+// parseStreamLine extracts the query from one input line. Lines that look like JSON
+// must decode; anything else is taken verbatim.
+func parseStreamLine(line []byte) (string, bool) {
+	switch line[0] {
+	case '"':
+		var query string
+		if err := json.Unmarshal(line, &query); err != nil {
+			return "", false
+		}
+		return query, true
+	case '{':
+		var query streamQuery
+		if err := json.Unmarshal(line, &query); err != nil {
+			return "", false
+		}
+		return query.Query, true
+	}
+	return string(line), true
+}
@@ -24,13 +24,38 @@ var (
 		[]string{"method", "endpoint", "status_code"},
 	)
 
+	// gRPC request metrics, labelled like their HTTP counterparts
+	GRPCRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_requests_total",
+			Help: "Total number of gRPC requests",
+		},
+		[]string{"method", "code"},
+	)
+
+	GRPCRequestDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "grpc_request_duration_seconds",
+			Help:    "gRPC request duration in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"method", "code"},
+	)
+
+	GRPCActiveCalls = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "grpc_active_calls",
+			Help: "Number of gRPC calls and streams in progress",
+		},
+	)
+
 	// Business logic metrics - Enhanced for success/failure tracking
 	CountryLookupsTotal = promauto.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "country_lookups_total",
 			Help: "Total number of country lookups by result type",
 		},
-		[]string{"result"}, // "success", "not_found", "validation_error", "error"
+		[]string{"result"}, // "success", "not_found", "ambiguous", "validation_error", "error"
 	)
 
 	CountryLookupDuration = promauto.NewHistogramVec(
@@ -51,6 +76,32 @@ var (
 		[]string{"time_window"}, // "1m", "5m", "15m" etc
 	)
 
+	// Bulk request metrics
+	BatchSize = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "country_batch_size",
+			Help:    "Number of queries per bulk request",
+			Buckets: []float64{1, 10, 50, 100, 250, 500, 1000, 5000},
+		},
+		[]string{"endpoint"},
+	)
+
+	// Data reload metrics
+	DataReloadsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "country_data_reloads_total",
+			Help: "Total number of country data reloads by trigger and result",
+		},
+		[]string{"trigger", "result"}, // trigger: file, signal, admin; result: success, failure
+	)
+
+	DataLastReloadTimestamp = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "country_data_last_reload_timestamp_seconds",
+			Help: "Unix time of the last successful country data reload",
+		},
+	)
+
 	// Popular countries metrics
 	PopularCountries = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -0,0 +1,186 @@
+package reload
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"country-iso-matcher/src/internal/metrics"
+)
+
+// Triggers recorded in logs and metrics
+const (
+	TriggerFile   = "file"
+	TriggerSignal = "signal"
+	TriggerAdmin  = "admin"
+)
+
+// Reloadable is a data store that can rebuild itself from its source.
+// Reload returns the number of countries loaded and must leave the old data in place on error.
+// Collisions returns the names of the current data that resolve to more than one country.
+type Reloadable interface {
+	Reload() (int, error)
+	Collisions() map[string][]string
+}
+
+// Result describes a successful reload
+type Result struct {
+	Trigger   string
+	Countries int
+	Duration  time.Duration
+}
+
+// Reloader serializes reloads of the country data coming from the file watcher,
+// SIGHUP and the admin endpoint, and records their outcome
+type Reloader struct {
+	target Reloadable
+	logger *slog.Logger
+	mu     sync.Mutex
+}
+
+func NewReloader(target Reloadable, logger *slog.Logger) *Reloader {
+	return &Reloader{
+		target: target,
+		logger: logger,
+	}
+}
+
+// Reload rebuilds the country data. Concurrent calls run one after another.
+func (r *Reloader) Reload(trigger string) (*Result, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	start := time.Now()
+	countries, err := r.target.Reload()
+	duration := time.Since(start)
+
+	if err != nil {
+		metrics.DataReloadsTotal.WithLabelValues(trigger, "failure").Inc()
+		r.logger.Error("country data reload failed, keeping previous data",
+			"trigger", trigger,
+			"error", err,
+			"duration_ms", duration.Milliseconds(),
+		)
+		return nil, err
+	}
+
+	metrics.DataReloadsTotal.WithLabelValues(trigger, "success").Inc()
+	metrics.DataLastReloadTimestamp.SetToCurrentTime()
+	r.logger.Info("country data reloaded",
+		"trigger", trigger,
+		"countries", countries,
+		"duration_ms", duration.Milliseconds(),
+	)
+	LogCollisions(r.logger, r.target.Collisions())
+
+	return &Result{Trigger: trigger, Countries: countries, Duration: duration}, nil
+}
+
+// WatchSignals reloads on every SIGHUP until ctx is done
+func (r *Reloader) WatchSignals(ctx context.Context) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+	defer signal.Stop(sigCh)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sigCh:
+			r.Reload(TriggerSignal)
+		}
+	}
+}
+
+// WatchFiles polls the files matching patterns every interval and reloads when any of them
+// is added, removed or modified, until ctx is done. A change is seen once it reloads.
+func (r *Reloader) WatchFiles(ctx context.Context, patterns []string, interval time.Duration) {
+	if len(patterns) == 0 || interval <= 0 {
+		return
+	}
+
+	r.logger.Info("watching country data files", "patterns", patterns, "interval", interval.String())
+
+	last, err := fingerprint(patterns)
+	if err != nil {
+		r.logger.Warn("failed to stat country data files", "error", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current, err := fingerprint(patterns)
+		if err != nil {
+			r.logger.Warn("failed to stat country data files", "error", err)
+			continue
+		}
+		if current == last {
+			continue
+		}
+
+		// A failed reload (e.g. of a half-written file) is retried on the next tick
+		if _, err := r.Reload(TriggerFile); err != nil {
+			continue
+		}
+		last = current
+	}
+}
+
+// LogCollisions reports names that resolve to more than one country so the data can be fixed
+func LogCollisions(logger *slog.Logger, collisions map[string][]string) {
+	if len(collisions) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(collisions))
+	for name := range collisions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		logger.Warn("ambiguous country name in data", "name", name, "countries", collisions[name])
+	}
+	logger.Warn("country data contains ambiguous names", "count", len(collisions))
+}
+
+// fingerprint summarizes the name, size and modification time of every matching file
+func fingerprint(patterns []string) (string, error) {
+	var files []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return "", fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		files = append(files, matches...)
+	}
+	sort.Strings(files)
+
+	hash := sha256.New()
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			// Removed between Glob and Stat; it changes the fingerprint like a removal
+			fmt.Fprintf(hash, "%s|missing\n", file)
+			continue
+		}
+		fmt.Fprintf(hash, "%s|%d|%d\n", file, info.Size(), info.ModTime().UnixNano())
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
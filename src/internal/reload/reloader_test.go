@@ -0,0 +1,186 @@
+package reload_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"country-iso-matcher/src/internal/config"
+	"country-iso-matcher/src/internal/domain"
+	"country-iso-matcher/src/internal/reload"
+	"country-iso-matcher/src/internal/repository/memory"
+	"country-iso-matcher/src/pkg/normalizer"
+)
+
+// switchLoader serves whatever countries the test sets before the next reload
+type switchLoader struct {
+	countries []domain.Country
+}
+
+func (l *switchLoader) LoadCountries() ([]domain.Country, error) { return l.countries, nil }
+
+func (l *switchLoader) LoadAliases() (map[string][]string, error) { return nil, nil }
+
+var (
+	germany = domain.Country{ISO2: "DE", ISO3: "DEU", Names: map[string]string{"en": "Germany"}}
+	france  = domain.Country{ISO2: "FR", ISO3: "FRA", Names: map[string]string{"en": "France"}}
+)
+
+func TestReloader_KeepsDataOnInvalidReload(t *testing.T) {
+	loader := &switchLoader{countries: []domain.Country{germany}}
+	matching := config.DefaultConfig().Matching
+	repo, err := memory.NewCountryRepository(normalizer.NewTextNormalizer(), loader, &matching)
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+
+	var logs bytes.Buffer
+	reloader := reload.NewReloader(repo, slog.New(slog.NewTextHandler(&logs, nil)))
+
+	tests := []struct {
+		name          string
+		countries     []domain.Country
+		expectedError bool
+		found         []string
+		missing       []string
+	}{
+		{
+			name:          "duplicate ISO code",
+			countries:     []domain.Country{germany, france, {ISO2: "FR", ISO3: "FXX", Names: map[string]string{"en": "Metropolitan France"}}},
+			expectedError: true,
+			found:         []string{"DE"},
+			missing:       []string{"FR"},
+		},
+		{
+			name:          "no countries",
+			countries:     nil,
+			expectedError: true,
+			found:         []string{"DE"},
+		},
+		{
+			name:      "valid data replaces the previous data",
+			countries: []domain.Country{france},
+			found:     []string{"FR"},
+			missing:   []string{"DE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader.countries = tt.countries
+			result, err := reloader.Reload(reload.TriggerAdmin)
+			if tt.expectedError {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+			} else if err != nil || result.Countries != len(tt.countries) {
+				t.Fatalf("expected %d countries, got %+v, %v", len(tt.countries), result, err)
+			}
+
+			for _, code := range tt.found {
+				if _, err := repo.FindByCode(code); err != nil {
+					t.Errorf("expected %s to be served, got %v", code, err)
+				}
+			}
+			for _, code := range tt.missing {
+				if _, err := repo.FindByCode(code); err == nil {
+					t.Errorf("expected %s not to be served", code)
+				}
+			}
+		})
+	}
+
+	if !strings.Contains(logs.String(), "keeping previous data") {
+		t.Errorf("expected failed reloads to be logged, got %s", logs.String())
+	}
+}
+
+func TestReloader_LogsCollisions(t *testing.T) {
+	loader := &switchLoader{countries: []domain.Country{germany, france}}
+	matching := config.DefaultConfig().Matching
+	repo, err := memory.NewCountryRepository(normalizer.NewTextNormalizer(), loader, &matching)
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+
+	var logs bytes.Buffer
+	reloader := reload.NewReloader(repo, slog.New(slog.NewTextHandler(&logs, nil)))
+
+	loader.countries = []domain.Country{
+		{ISO2: "CG", ISO3: "COG", Names: map[string]string{"en": "Congo"}},
+		{ISO2: "CD", ISO3: "COD", Names: map[string]string{"en": "Democratic Republic of the Congo", "fr": "Congo"}},
+	}
+	if _, err := reloader.Reload(reload.TriggerFile); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(logs.String(), "ambiguous country name in data") || !strings.Contains(logs.String(), "name=congo") {
+		t.Errorf("expected the collision to be logged, got %s", logs.String())
+	}
+}
+
+// flakyTarget fails its first reloads, like a file read while it is still being written
+type flakyTarget struct {
+	failures atomic.Int32
+	calls    atomic.Int32
+}
+
+func (f *flakyTarget) Reload() (int, error) {
+	f.calls.Add(1)
+	if f.failures.Add(-1) >= 0 {
+		return 0, errors.New("unexpected end of JSON input")
+	}
+	return 1, nil
+}
+
+func (f *flakyTarget) Collisions() map[string][]string { return nil }
+
+func TestReloader_WatchFilesRetriesFailedReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "countries.json")
+	if err := os.WriteFile(path, []byte("[]"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	target := &flakyTarget{}
+	target.failures.Store(2)
+	reloader := reload.NewReloader(target, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		reloader.WatchFiles(ctx, []string{path}, 10*time.Millisecond)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	time.Sleep(30 * time.Millisecond)
+	if err := os.WriteFile(path, []byte(`[{"iso2":"DE"`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The file does not change again: the failed reloads must be retried until one succeeds
+	deadline := time.Now().Add(2 * time.Second)
+	for target.calls.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if calls := target.calls.Load(); calls < 3 {
+		t.Fatalf("expected failed reloads to be retried, got %d reloads", calls)
+	}
+
+	// Once a reload succeeds the change counts as seen
+	time.Sleep(50 * time.Millisecond)
+	if calls := target.calls.Load(); calls != 3 {
+		t.Errorf("expected no reloads after the successful one, got %d reloads", calls)
+	}
+}
@@ -5,4 +5,43 @@ import "country-iso-matcher/src/internal/domain"
 type CountryRepository interface {
 	FindByName(name string) (*domain.Country, error)
 	FindByCode(code string) (*domain.Country, error)
+
+	// Countries returns every country, sorted by ISO2
+	Countries() []*domain.Country
+
+	// Aliases returns the aliases of the country with an ISO2 code
+	Aliases(iso2 string) []string
+
+	// FindByCodeIn returns every country with a canonical code in a code system
+	FindByCodeIn(system, code string) []*domain.Country
+
+	// FindHistorical resolves the ISO 3166-3 code or former ISO 3166-1 code of a withdrawn country
+	FindHistorical(code string) (*domain.Match, error)
+
+	// MatchSubdivision resolves the name or code of a subdivision, optionally within one country
+	MatchSubdivision(query, country string) (*domain.Match, error)
+
+	// Subdivisions returns the subdivisions of a country, sorted by code
+	Subdivisions(country string) []*domain.Subdivision
+
+	// PostalCodeFormats returns the postal code formats of a country
+	PostalCodeFormats(country string) []*domain.PostalCodeFormat
+
+	// PostalCodeCountries returns every country with a postal code format matching code
+	PostalCodeCountries(code string) []*domain.Country
+
+	// MatchByName resolves a name like FindByName but also reports how it matched
+	MatchByName(name string) (*domain.Match, error)
+
+	// MatchDemonym resolves a demonym such as "German" to its country
+	MatchDemonym(name string) (*domain.Match, error)
+
+	// Suggest returns up to limit ranked candidate countries for a name
+	Suggest(name string, limit int) []*domain.Match
+
+	// Extract finds the countries mentioned in a free text, in order of appearance
+	Extract(text string) []domain.Extraction
+
+	// Autocomplete returns up to limit countries with a name, alias or code starting with prefix
+	Autocomplete(prefix string, limit int) []*domain.Match
 }
@@ -0,0 +1,687 @@
+package memory
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"country-iso-matcher/src/internal/data"
+	"country-iso-matcher/src/internal/domain"
+	"country-iso-matcher/src/pkg/normalizer"
+)
+
+// indexEntry is what a normalized key in the name index resolves to
+type indexEntry struct {
+	code    string              // ISO2 code of the country
+	origins []domain.Provenance // Every name, alias or code of the country that normalizes to the key
+}
+
+// matchType reports how the key relates to the country, based on its most specific origin
+func (e indexEntry) matchType() domain.MatchType {
+	switch e.origins[0].Source {
+	case domain.MatchSourceAlias:
+		return domain.MatchTypeAlias
+	case domain.MatchSourceISO2, domain.MatchSourceISO3, domain.MatchSourceNumeric:
+		return domain.MatchTypeCode
+	default:
+		return domain.MatchTypeExact
+	}
+}
+
+// historicalEntry is a withdrawn country with its successors resolved against the current data
+type historicalEntry struct {
+	country    *domain.HistoricalCountry
+	self       *domain.Country   // The historical country as a country record
+	successors []*domain.Country // Current successors, sorted by ISO2
+	mapped     *domain.Country   // Configured single successor, if any
+}
+
+// targetKey is one of the records a normalized key of the historical or subdivision index resolves to
+type targetKey[T comparable] struct {
+	target  T
+	origins []domain.Provenance // Every name, alias or code of the record that normalizes to the key
+}
+
+// demonymEntry is a country a normalized demonym key resolves to
+type demonymEntry struct {
+	code  string               // ISO2 code of the country
+	forms []domain.DemonymInfo // Every demonym of the country that normalizes to the key
+}
+
+// countryIndex is one loaded snapshot of the country data with all its lookup structures.
+// It is never modified after buildIndex returns, so a reload builds a new one and swaps it in.
+type countryIndex struct {
+	nameToCode    map[string]indexEntry
+	ambiguous     map[string][]indexEntry // Keys claimed by more than one country, sorted by code
+	prefixes      *prefixIndex
+	codeToCountry map[string]*domain.Country
+	list          []*domain.Country                           // Every country, sorted by ISO2
+	aliases       map[string][]string                         // ISO2 -> aliases from the loader
+	systems       map[string]map[string][]*domain.Country     // Code system -> code -> countries, sorted by ISO2
+	historical    map[string][]targetKey[*historicalEntry]    // Keys of withdrawn countries; several entries make a key ambiguous
+	subdivisions  map[string][]targetKey[*domain.Subdivision] // Keys of subdivisions, sorted by code
+	bySubdivided  map[string][]*domain.Subdivision            // ISO2 -> subdivisions of the country, sorted by code
+	postalCodes   map[string][]*domain.PostalCodeFormat       // ISO2 -> postal code formats of the country
+	demonyms      map[string][]demonymEntry                   // Demonym keys, sorted by code; several entries make a key ambiguous
+	maxWords      int                                         // Most words in a key, bounding the windows of Extract
+	countries     int
+	normalizer    normalizer.TextNormalizer
+}
+
+// buildIndex loads countries and aliases from the loader, validates them and indexes every
+// name, code and alias. Loaders that implement data.HistoricalLoader also provide withdrawn
+// countries; successors maps their ISO 3166-3 codes to the current country they resolve to.
+func buildIndex(normalizer normalizer.TextNormalizer, loader data.Loader, successors map[string]string) (*countryIndex, error) {
+	// Load countries and aliases, from one snapshot when the loader supports it
+	loaded, err := data.Load(loader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load country data: %w", err)
+	}
+	countries, aliases := loaded.Countries, loaded.Aliases
+
+	idx := &countryIndex{
+		nameToCode:    make(map[string]indexEntry),
+		ambiguous:     make(map[string][]indexEntry),
+		codeToCountry: make(map[string]*domain.Country),
+		list:          make([]*domain.Country, 0, len(countries)),
+		aliases:       aliases,
+		systems:       make(map[string]map[string][]*domain.Country),
+		historical:    make(map[string][]targetKey[*historicalEntry]),
+		subdivisions:  make(map[string][]targetKey[*domain.Subdivision]),
+		bySubdivided:  make(map[string][]*domain.Subdivision),
+		postalCodes:   make(map[string][]*domain.PostalCodeFormat),
+		demonyms:      make(map[string][]demonymEntry),
+		countries:     len(countries),
+		normalizer:    normalizer,
+	}
+
+	if err := idx.validate(countries, aliases); err != nil {
+		return nil, fmt.Errorf("invalid country data: %w", err)
+	}
+
+	// Build country code map and add all names to lookup
+	for i := range countries {
+		country := &countries[i]
+
+		// Store by ISO2, ISO3 and numeric codes
+		idx.list = append(idx.list, country)
+		idx.codeToCountry[country.ISO2] = country
+		if country.ISO3 != "" {
+			idx.codeToCountry[country.ISO3] = country
+		}
+		if country.Numeric != "" {
+			idx.codeToCountry[country.Numeric] = country
+		}
+
+		// Index the country under every code system; codes may be shared (e.g. calling code 1)
+		for _, system := range domain.CodeSystems {
+			code := country.CodeIn(system)
+			if code == "" {
+				continue
+			}
+			if idx.systems[system] == nil {
+				idx.systems[system] = make(map[string][]*domain.Country)
+			}
+			idx.systems[system][code] = append(idx.systems[system][code], country)
+		}
+
+		// Add all multilingual names to lookup map, in language order so provenance is stable
+		langs := make([]string, 0, len(country.Names))
+		for lang := range country.Names {
+			langs = append(langs, lang)
+		}
+		sort.Strings(langs)
+		for _, lang := range langs {
+			idx.addKey(country.ISO2, domain.Provenance{
+				Source:   domain.MatchSourceName,
+				Language: lang,
+				Original: country.Names[lang],
+			})
+		}
+
+		// Demonyms go to their own index, so they never collide with names
+		idx.addDemonyms(country)
+
+		// Add ISO codes themselves as lookup keys
+		idx.addKey(country.ISO2, domain.Provenance{Source: domain.MatchSourceISO2, Original: country.ISO2})
+		if country.ISO3 != "" {
+			idx.addKey(country.ISO2, domain.Provenance{Source: domain.MatchSourceISO3, Original: country.ISO3})
+		}
+		if country.Numeric != "" {
+			idx.addKey(country.ISO2, domain.Provenance{Source: domain.MatchSourceNumeric, Original: country.Numeric})
+		}
+	}
+
+	sort.Slice(idx.list, func(i, j int) bool {
+		return idx.list[i].ISO2 < idx.list[j].ISO2
+	})
+
+	for _, codes := range idx.systems {
+		for _, countries := range codes {
+			sort.Slice(countries, func(i, j int) bool {
+				return countries[i].ISO2 < countries[j].ISO2
+			})
+		}
+	}
+
+	// Build alias lookup map
+	for isoCode, aliasNames := range aliases {
+		for _, alias := range aliasNames {
+			idx.addKey(isoCode, domain.Provenance{Source: domain.MatchSourceAlias, Original: alias})
+		}
+	}
+
+	var historical []domain.HistoricalCountry
+	if historicalLoader, ok := loader.(data.HistoricalLoader); ok {
+		historical, err = historicalLoader.LoadHistorical()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load historical countries: %w", err)
+		}
+	}
+	if err := idx.addHistorical(historical, successors); err != nil {
+		return nil, fmt.Errorf("invalid historical country data: %w", err)
+	}
+
+	var subdivisions []domain.Subdivision
+	if subdivisionLoader, ok := loader.(data.SubdivisionLoader); ok {
+		subdivisions, err = subdivisionLoader.LoadSubdivisions()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load subdivisions: %w", err)
+		}
+	}
+	if err := idx.addSubdivisions(subdivisions); err != nil {
+		return nil, fmt.Errorf("invalid subdivision data: %w", err)
+	}
+
+	var postalCodes []domain.PostalCodeFormat
+	if postalCodeLoader, ok := loader.(data.PostalCodeLoader); ok {
+		postalCodes, err = postalCodeLoader.LoadPostalCodes()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load postal code formats: %w", err)
+		}
+	}
+	if err := idx.addPostalCodes(postalCodes); err != nil {
+		return nil, fmt.Errorf("invalid postal code data: %w", err)
+	}
+
+	// Build the sorted prefix index for autocomplete
+	idx.prefixes = newPrefixIndex(idx.nameToCode, idx.ambiguous)
+	idx.maxWords = idx.maxKeyWords()
+
+	return idx, nil
+}
+
+// validate rejects data that would leave the index unusable: no countries, malformed or
+// duplicate ISO codes, countries without names and aliases for unknown countries.
+// Numeric codes are optional but must be three digits, and codes of other systems must be
+// in their canonical form.
+func (idx *countryIndex) validate(countries []domain.Country, aliases map[string][]string) error {
+	if len(countries) == 0 {
+		return fmt.Errorf("no countries loaded")
+	}
+
+	codes := make(map[string]bool, len(countries)*2)
+	numerics := make(map[string]string, len(countries))
+	for _, country := range countries {
+		// ISO3 is empty for loaders without an alpha-3 column
+		if len(country.ISO2) != 2 || (len(country.ISO3) != 3 && country.ISO3 != "") {
+			return fmt.Errorf("country %q/%q has malformed ISO codes", country.ISO2, country.ISO3)
+		}
+		if codes[country.ISO2] || (country.ISO3 != "" && codes[country.ISO3]) {
+			return fmt.Errorf("duplicate country code %s/%s", country.ISO2, country.ISO3)
+		}
+		codes[country.ISO2] = true
+		if country.ISO3 != "" {
+			codes[country.ISO3] = true
+		}
+
+		if country.Numeric != "" {
+			if numeric, ok := domain.NumericCode(country.Numeric); !ok || numeric != country.Numeric {
+				return fmt.Errorf("country %s has malformed numeric code %q", country.ISO2, country.Numeric)
+			}
+			if numerics[country.Numeric] != "" {
+				return fmt.Errorf("duplicate numeric code %s for %s and %s", country.Numeric, numerics[country.Numeric], country.ISO2)
+			}
+			numerics[country.Numeric] = country.ISO2
+		}
+
+		for system, code := range country.Codes {
+			if !domain.IsCodeSystem(system) {
+				return fmt.Errorf("country %s has a code in unknown code system %q", country.ISO2, system)
+			}
+			if normalized, ok := domain.NormalizeCode(system, code); !ok || normalized != code {
+				return fmt.Errorf("country %s has malformed %s code %q", country.ISO2, system, code)
+			}
+		}
+
+		if len(country.Names) == 0 {
+			return fmt.Errorf("country %s has no names", country.ISO2)
+		}
+	}
+
+	for code := range aliases {
+		if !codes[code] {
+			return fmt.Errorf("aliases reference unknown country %s", code)
+		}
+	}
+
+	return nil
+}
+
+// addKey indexes a name, alias or code for a country, recording where the key came from.
+// When the same country already owns the key, the origin is added to the existing entry;
+// the first origin (names before codes before aliases) decides the match type.
+// A key claimed by a different country is moved to the ambiguous index instead of being overwritten.
+func (idx *countryIndex) addKey(code string, origin domain.Provenance) {
+	normalized := idx.normalizer.Normalize(origin.Original)
+	if normalized == "" {
+		return
+	}
+
+	if entries, isAmbiguous := idx.ambiguous[normalized]; isAmbiguous {
+		idx.ambiguous[normalized] = mergeOrigin(entries, code, origin)
+		return
+	}
+
+	existing, exists := idx.nameToCode[normalized]
+	if !exists {
+		idx.nameToCode[normalized] = indexEntry{code: code, origins: []domain.Provenance{origin}}
+		return
+	}
+	if existing.code == code {
+		idx.nameToCode[normalized] = mergeOrigin([]indexEntry{existing}, code, origin)[0]
+		return
+	}
+
+	delete(idx.nameToCode, normalized)
+	idx.ambiguous[normalized] = mergeOrigin([]indexEntry{existing}, code, origin)
+}
+
+// addHistorical indexes the names, aliases and codes of withdrawn countries. Successors
+// missing from the current data are left out, but a configured successor must exist.
+func (idx *countryIndex) addHistorical(countries []domain.HistoricalCountry, successors map[string]string) error {
+	known := make(map[string]bool, len(countries))
+	for i := range countries {
+		country := &countries[i]
+		if known[country.Code] {
+			return fmt.Errorf("duplicate historical country %s", country.Code)
+		}
+		known[country.Code] = true
+
+		entry := &historicalEntry{country: country, self: country.AsCountry()}
+		for _, code := range country.Successors {
+			if successor, exists := idx.codeToCountry[code]; exists {
+				entry.successors = append(entry.successors, successor)
+			}
+		}
+		sort.Slice(entry.successors, func(i, j int) bool {
+			return entry.successors[i].ISO2 < entry.successors[j].ISO2
+		})
+
+		if code, configured := successors[country.Code]; configured {
+			successor, exists := idx.codeToCountry[code]
+			if !exists {
+				return fmt.Errorf("historical country %s maps to unknown successor %s", country.Code, code)
+			}
+			entry.mapped = successor
+		}
+
+		langs := make([]string, 0, len(country.Names))
+		for lang := range country.Names {
+			langs = append(langs, lang)
+		}
+		sort.Strings(langs)
+		for _, lang := range langs {
+			idx.addHistoricalKey(entry, domain.Provenance{
+				Source:   domain.MatchSourceName,
+				Language: lang,
+				Original: country.Names[lang],
+			})
+		}
+
+		idx.addHistoricalKey(entry, domain.Provenance{Source: domain.MatchSourceHistoricalCode, Original: country.Code})
+		idx.addHistoricalKey(entry, domain.Provenance{Source: domain.MatchSourceISO2, Original: country.ISO2})
+		idx.addHistoricalKey(entry, domain.Provenance{Source: domain.MatchSourceISO3, Original: country.ISO3})
+		if country.Numeric != "" {
+			idx.addHistoricalKey(entry, domain.Provenance{Source: domain.MatchSourceNumeric, Original: country.Numeric})
+		}
+		for _, alias := range country.Aliases {
+			idx.addHistoricalKey(entry, domain.Provenance{Source: domain.MatchSourceAlias, Original: alias})
+		}
+	}
+
+	for code := range successors {
+		if !known[code] {
+			return fmt.Errorf("successor configured for unknown historical country %s", code)
+		}
+	}
+
+	return nil
+}
+
+// addHistoricalKey indexes a name, alias or code of a withdrawn country. Keys shared by
+// several withdrawn countries (e.g. CS, used by Czechoslovakia and Serbia and Montenegro)
+// keep all of them, sorted by ISO 3166-3 code.
+func (idx *countryIndex) addHistoricalKey(entry *historicalEntry, origin domain.Provenance) {
+	normalized := idx.normalizer.Normalize(origin.Original)
+	if normalized == "" {
+		return
+	}
+
+	idx.historical[normalized] = addTargetKey(idx.historical[normalized], entry, origin, func(a, b *historicalEntry) bool {
+		return a.country.Code < b.country.Code
+	})
+}
+
+// addSubdivisions indexes the names, aliases and codes of subdivisions. Subdivisions of
+// countries missing from the current data are left out.
+func (idx *countryIndex) addSubdivisions(subdivisions []domain.Subdivision) error {
+	known := make(map[string]bool, len(subdivisions))
+	for i := range subdivisions {
+		subdivision := &subdivisions[i]
+		country, local, ok := domain.SplitSubdivisionCode(subdivision.Code)
+		if !ok || country+"-"+local != subdivision.Code || country != subdivision.Country {
+			return fmt.Errorf("subdivision %q of %q has a malformed code", subdivision.Code, subdivision.Country)
+		}
+		if known[subdivision.Code] {
+			return fmt.Errorf("duplicate subdivision %s", subdivision.Code)
+		}
+		known[subdivision.Code] = true
+
+		if len(subdivision.Names) == 0 {
+			return fmt.Errorf("subdivision %s has no names", subdivision.Code)
+		}
+		if _, exists := idx.codeToCountry[country]; !exists {
+			continue
+		}
+		idx.bySubdivided[country] = append(idx.bySubdivided[country], subdivision)
+
+		langs := make([]string, 0, len(subdivision.Names))
+		for lang := range subdivision.Names {
+			langs = append(langs, lang)
+		}
+		sort.Strings(langs)
+		for _, lang := range langs {
+			idx.addSubdivisionKey(subdivision, domain.Provenance{
+				Source:   domain.MatchSourceName,
+				Language: lang,
+				Original: subdivision.Names[lang],
+			})
+		}
+
+		idx.addSubdivisionKey(subdivision, domain.Provenance{Source: domain.MatchSourceSubdivisionCode, Original: subdivision.Code})
+		// Purely numeric local codes (e.g. AT-9, JP-13) mean nothing without the country
+		if strings.ContainsFunc(local, func(r rune) bool { return r >= 'A' && r <= 'Z' }) {
+			idx.addSubdivisionKey(subdivision, domain.Provenance{Source: domain.MatchSourceSubdivisionCode, Original: local})
+		}
+		for _, alias := range subdivision.Aliases {
+			idx.addSubdivisionKey(subdivision, domain.Provenance{Source: domain.MatchSourceAlias, Original: alias})
+		}
+	}
+
+	for _, list := range idx.bySubdivided {
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].Code < list[j].Code
+		})
+	}
+
+	return nil
+}
+
+// addDemonyms indexes every demonym of a country, in language order. Keys shared by
+// several countries (e.g. "Dominican") keep all of them, sorted by code.
+func (idx *countryIndex) addDemonyms(country *domain.Country) {
+	langs := make([]string, 0, len(country.Demonyms))
+	for lang := range country.Demonyms {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	for _, lang := range langs {
+		for _, demonym := range country.Demonyms[lang] {
+			normalized := idx.normalizer.Normalize(demonym.Text)
+			if normalized == "" {
+				continue
+			}
+			info := domain.DemonymInfo{Demonym: demonym, Language: lang}
+
+			entries := idx.demonyms[normalized]
+			i := sort.Search(len(entries), func(i int) bool { return entries[i].code >= country.ISO2 })
+			if i < len(entries) && entries[i].code == country.ISO2 {
+				entries[i].forms = append(entries[i].forms, info)
+				continue
+			}
+			entries = append(entries, demonymEntry{})
+			copy(entries[i+1:], entries[i:])
+			entries[i] = demonymEntry{code: country.ISO2, forms: []domain.DemonymInfo{info}}
+			idx.demonyms[normalized] = entries
+		}
+	}
+}
+
+// newDemonymMatch builds a match for a demonym key of one country
+func (idx *countryIndex) newDemonymMatch(key string, entry demonymEntry) *domain.Match {
+	match := &domain.Match{
+		Country:     idx.codeToCountry[entry.code],
+		Type:        domain.MatchTypeDemonym,
+		MatchedName: key,
+		Score:       scoreDemonym,
+		Demonyms:    entry.forms,
+	}
+	for _, form := range entry.forms {
+		origin := domain.Provenance{Source: domain.MatchSourceDemonym, Language: form.Language, Original: form.Text}
+		// Forms differing only in gender or number (e.g. "Deutsche") share their origin
+		if !slices.Contains(match.Provenance, origin) {
+			match.Provenance = append(match.Provenance, origin)
+		}
+	}
+	return match
+}
+
+// addPostalCodes compiles the postal code formats and files them under their country.
+// Formats of countries missing from the current data are left out.
+func (idx *countryIndex) addPostalCodes(formats []domain.PostalCodeFormat) error {
+	for i := range formats {
+		format := &formats[i]
+		if err := format.Compile(); err != nil {
+			return err
+		}
+		if _, exists := idx.codeToCountry[format.Country]; !exists {
+			continue
+		}
+		idx.postalCodes[format.Country] = append(idx.postalCodes[format.Country], format)
+	}
+	return nil
+}
+
+// addSubdivisionKey indexes a name, alias or code of a subdivision. Keys shared by several
+// subdivisions (e.g. the local code NT of AU-NT and CA-NT) keep all of them, sorted by code.
+func (idx *countryIndex) addSubdivisionKey(subdivision *domain.Subdivision, origin domain.Provenance) {
+	normalized := idx.normalizer.Normalize(origin.Original)
+	if normalized == "" {
+		return
+	}
+
+	idx.subdivisions[normalized] = addTargetKey(idx.subdivisions[normalized], subdivision, origin, func(a, b *domain.Subdivision) bool {
+		return a.Code < b.Code
+	})
+}
+
+// addTargetKey adds an origin of target to the records of one normalized key, keeping
+// the records sorted by less
+func addTargetKey[T comparable](keys []targetKey[T], target T, origin domain.Provenance, less func(a, b T) bool) []targetKey[T] {
+	for i := range keys {
+		if keys[i].target != target {
+			continue
+		}
+		for _, existing := range keys[i].origins {
+			if existing == origin {
+				return keys
+			}
+		}
+		keys[i].origins = append(keys[i].origins, origin)
+		return keys
+	}
+
+	keys = append(keys, targetKey[T]{target: target, origins: []domain.Provenance{origin}})
+	sort.Slice(keys, func(i, j int) bool {
+		return less(keys[i].target, keys[j].target)
+	})
+	return keys
+}
+
+// newHistoricalMatch builds a match for a key of a withdrawn country. The match reports
+// the configured successor as its country, or the historical country itself.
+func (idx *countryIndex) newHistoricalMatch(key string, hk targetKey[*historicalEntry]) *domain.Match {
+	country := hk.target.mapped
+	if country == nil {
+		country = hk.target.self
+	}
+
+	return &domain.Match{
+		Country:     country,
+		Type:        domain.MatchTypeHistorical,
+		MatchedName: key,
+		Provenance:  hk.origins,
+		Score:       scoreHistorical,
+		Historical:  hk.target.country,
+		Successors:  hk.target.successors,
+	}
+}
+
+// newSubdivisionMatch builds a match for a key of a subdivision, with the subdivision's
+// country as the match's country
+func (idx *countryIndex) newSubdivisionMatch(key string, sk targetKey[*domain.Subdivision]) *domain.Match {
+	match := &domain.Match{
+		Country:     idx.codeToCountry[sk.target.Country],
+		MatchedName: key,
+		Provenance:  sk.origins,
+		Subdivision: sk.target,
+	}
+
+	switch sk.origins[0].Source {
+	case domain.MatchSourceAlias:
+		match.Type, match.Score = domain.MatchTypeAlias, scoreAlias
+	case domain.MatchSourceSubdivisionCode:
+		match.Type, match.Score = domain.MatchTypeCode, scoreCode
+	default:
+		match.Type, match.Score = domain.MatchTypeExact, scoreName
+	}
+
+	return match
+}
+
+// historicalCountries returns the withdrawn countries sharing a key
+func historicalCountries(keys []targetKey[*historicalEntry]) []*domain.Country {
+	countries := make([]*domain.Country, 0, len(keys))
+	for _, hk := range keys {
+		countries = append(countries, hk.target.self)
+	}
+	return countries
+}
+
+// subdivisionsOf returns the subdivisions sharing a key
+func subdivisionsOf(keys []targetKey[*domain.Subdivision]) []*domain.Subdivision {
+	subdivisions := make([]*domain.Subdivision, 0, len(keys))
+	for _, sk := range keys {
+		subdivisions = append(subdivisions, sk.target)
+	}
+	return subdivisions
+}
+
+// collisions returns every normalized name that maps to more than one country,
+// with the ISO2 codes claiming it
+func (idx *countryIndex) collisions() map[string][]string {
+	collisions := make(map[string][]string, len(idx.ambiguous))
+	for key, entries := range idx.ambiguous {
+		codes := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			codes = append(codes, entry.code)
+		}
+		collisions[key] = codes
+	}
+	return collisions
+}
+
+// newMatch builds a match for an index hit, scored by how the key relates to the country
+func (idx *countryIndex) newMatch(key string, entry indexEntry) *domain.Match {
+	match := &domain.Match{
+		Country:     idx.codeToCountry[entry.code],
+		Type:        entry.matchType(),
+		MatchedName: key,
+		Provenance:  entry.origins,
+	}
+
+	switch match.Type {
+	case domain.MatchTypeAlias:
+		match.Score = scoreAlias
+	case domain.MatchTypeCode:
+		match.Score = scoreCode
+	default:
+		match.Score = scoreName
+	}
+
+	return match
+}
+
+// forEachEntry calls fn for every indexed key, including each country sharing an ambiguous key
+func (idx *countryIndex) forEachEntry(fn func(key string, entry indexEntry)) {
+	for key, entry := range idx.nameToCode {
+		fn(key, entry)
+	}
+	for key, entries := range idx.ambiguous {
+		for _, entry := range entries {
+			fn(key, entry)
+		}
+	}
+}
+
+// countriesFor resolves index entries to their countries
+func (idx *countryIndex) countriesFor(entries []indexEntry) []*domain.Country {
+	countries := make([]*domain.Country, 0, len(entries))
+	for _, entry := range entries {
+		countries = append(countries, idx.codeToCountry[entry.code])
+	}
+	return countries
+}
+
+// mergeOrigin adds an origin to the entry for code, creating the entry if needed.
+// The result is sorted by code.
+func mergeOrigin(entries []indexEntry, code string, origin domain.Provenance) []indexEntry {
+	for i := range entries {
+		if entries[i].code != code {
+			continue
+		}
+		for _, existing := range entries[i].origins {
+			if existing == origin {
+				return entries
+			}
+		}
+		entries[i].origins = append(entries[i].origins, origin)
+		return entries
+	}
+
+	entries = append(entries, indexEntry{code: code, origins: []domain.Provenance{origin}})
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].code < entries[j].code
+	})
+	return entries
+}
+
+// uniqueByCode keeps the first entry per country and sorts the result by code
+func uniqueByCode(entries []indexEntry) []indexEntry {
+	seen := make(map[string]bool, len(entries))
+	unique := make([]indexEntry, 0, len(entries))
+	for _, entry := range entries {
+		if !seen[entry.code] {
+			seen[entry.code] = true
+			unique = append(unique, entry)
+		}
+	}
+
+	sort.Slice(unique, func(i, j int) bool {
+		return unique[i].code < unique[j].code
+	})
+	return unique
+}
@@ -2,93 +2,505 @@ package memory
 
 import (
 	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync/atomic"
 
+	"country-iso-matcher/src/internal/config"
 	"country-iso-matcher/src/internal/data"
 	"country-iso-matcher/src/internal/domain"
+	"country-iso-matcher/src/pkg/fuzzy"
 	"country-iso-matcher/src/pkg/normalizer"
 )
 
+// Scores assigned to suggestion candidates by match type
+const (
+	scoreName        = 1.0
+	scoreAlias       = 0.95
+	scoreCode        = 0.9
+	scorePrefixBase  = 0.5
+	scorePrefixRange = 0.4
+	scoreFuzzyMax    = 0.85
+	scoreHistorical  = 0.9
+	scoreSubdivision = 0.8
+	scoreDemonym     = 0.95
+
+	// minPrefixLength is the shortest query that is used for prefix suggestions
+	minPrefixLength = 2
+)
+
 type countryRepository struct {
-	nameToCode    map[string]string
-	codeToCountry map[string]*domain.Country
-	normalizer    normalizer.TextNormalizer
+	// index is replaced as a whole on reload; every lookup loads it once and works on that snapshot
+	index      atomic.Pointer[countryIndex]
+	loader     data.Loader
+	normalizer normalizer.TextNormalizer
+	matching   config.MatchingConfig
 }
 
 // NewCountryRepository creates a new in-memory country repository
 // It uses a data loader to load country data from various sources (CSV, TSV, memory, database)
-func NewCountryRepository(normalizer normalizer.TextNormalizer, loader data.Loader) (*countryRepository, error) {
+func NewCountryRepository(normalizer normalizer.TextNormalizer, loader data.Loader, matching *config.MatchingConfig) (*countryRepository, error) {
 	repo := &countryRepository{
-		nameToCode:    make(map[string]string),
-		codeToCountry: make(map[string]*domain.Country),
-		normalizer:    normalizer,
+		loader:     loader,
+		normalizer: normalizer,
+	}
+	if matching != nil {
+		repo.matching = *matching
 	}
 
-	if err := repo.loadCountries(loader); err != nil {
+	idx, err := buildIndex(normalizer, loader, repo.matching.HistoricalSuccessors)
+	if err != nil {
 		return nil, fmt.Errorf("failed to load country data: %w", err)
 	}
+	repo.index.Store(idx)
 
 	return repo, nil
 }
 
+// Reload reads the data from the loader again and swaps in the new index once it is
+// complete and valid. Lookups in flight keep using the previous index; when loading or
+// validation fails, the previous index stays in place. It returns the number of countries loaded.
+func (r *countryRepository) Reload() (int, error) {
+	idx, err := buildIndex(r.normalizer, r.loader, r.matching.HistoricalSuccessors)
+	if err != nil {
+		return 0, err
+	}
+
+	r.index.Store(idx)
+	return idx.countries, nil
+}
+
 // FindByName finds a country by its name (supports aliases and fuzzy matching)
 func (r *countryRepository) FindByName(name string) (*domain.Country, error) {
+	match, err := r.MatchByName(name)
+	if err != nil {
+		return nil, err
+	}
+	return match.Country, nil
+}
+
+// MatchByName finds a country by its name and reports how the query matched.
+// Current countries take precedence over withdrawn ones (ISO 3166-3), which are matched
+// before the fuzzy fallback runs. With the subdivision fallback enabled, the name or code
+// of a subdivision (ISO 3166-2) then resolves to its country. Names shared by several
+// countries yield an ambiguous error listing the candidates.
+func (r *countryRepository) MatchByName(name string) (*domain.Match, error) {
+	idx := r.index.Load()
+
 	normalized := r.normalizer.Normalize(name)
-	code, exists := r.nameToCode[normalized]
-	if !exists {
+	if numeric, ok := domain.NumericCode(normalized); ok {
+		normalized = numeric // "76" and "0076" both find "076"
+	}
+	if entry, exists := idx.nameToCode[normalized]; exists {
+		match := idx.newMatch(normalized, entry)
+		match.NormalizedQuery = normalized
+		return match, nil
+	}
+
+	if entries, exists := idx.ambiguous[normalized]; exists {
+		return nil, domain.NewAmbiguousError(name, idx.countriesFor(entries))
+	}
+
+	if keys, exists := idx.historical[normalized]; exists {
+		if len(keys) > 1 {
+			return nil, domain.NewAmbiguousError(name, historicalCountries(keys))
+		}
+		match := idx.newHistoricalMatch(normalized, keys[0])
+		match.NormalizedQuery = normalized
+		return match, nil
+	}
+
+	if keys, exists := idx.subdivisions[normalized]; exists && r.matching.SubdivisionFallback {
+		match, err := idx.subdivisionFallback(name, normalized, keys)
+		if err != nil {
+			return nil, err
+		}
+		match.NormalizedQuery = normalized
+		return match, nil
+	}
+
+	match, err := r.fuzzyMatch(idx, name, normalized)
+	if err != nil {
+		return nil, err
+	}
+	if match != nil {
+		match.NormalizedQuery = normalized
+		return match, nil
+	}
+
+	return nil, domain.NewNotFoundError(name)
+}
+
+// MatchDemonym resolves a demonym (e.g. "German", "Française") to its country. Demonyms
+// are only looked up exactly; a demonym of several countries (e.g. "Dominican") yields an
+// ambiguous error listing them.
+func (r *countryRepository) MatchDemonym(name string) (*domain.Match, error) {
+	idx := r.index.Load()
+
+	normalized := r.normalizer.Normalize(name)
+	entries, exists := idx.demonyms[normalized]
+	switch {
+	case !exists:
 		return nil, domain.NewNotFoundError(name)
+	case len(entries) > 1:
+		countries := make([]*domain.Country, 0, len(entries))
+		for _, entry := range entries {
+			countries = append(countries, idx.codeToCountry[entry.code])
+		}
+		return nil, domain.NewAmbiguousError(name, countries)
 	}
-	return r.codeToCountry[code], nil
+
+	match := idx.newDemonymMatch(normalized, entries[0])
+	match.NormalizedQuery = normalized
+	return match, nil
 }
 
-// FindByCode finds a country by its ISO code
+// Collisions returns every normalized name that maps to more than one country,
+// with the ISO2 codes claiming it
+func (r *countryRepository) Collisions() map[string][]string {
+	return r.index.Load().collisions()
+}
+
+// FindByCode finds a country by its ISO alpha-2, alpha-3 or numeric code.
+// Numeric codes may omit or add leading zeros.
 func (r *countryRepository) FindByCode(code string) (*domain.Country, error) {
-	country, exists := r.codeToCountry[code]
+	if numeric, ok := domain.NumericCode(code); ok {
+		code = numeric
+	}
+	country, exists := r.index.Load().codeToCountry[code]
 	if !exists {
 		return nil, domain.NewNotFoundError(code)
 	}
 	return country, nil
 }
 
-// loadCountries loads country data and aliases from the data loader
-func (r *countryRepository) loadCountries(loader data.Loader) error {
-	// Load countries
-	countries, err := loader.LoadCountries()
-	if err != nil {
-		return fmt.Errorf("failed to load countries: %w", err)
+// Countries returns every country, sorted by ISO2
+func (r *countryRepository) Countries() []*domain.Country {
+	return r.index.Load().list
+}
+
+// Aliases returns the aliases of the country with an ISO2 code, in load order
+func (r *countryRepository) Aliases(iso2 string) []string {
+	return r.index.Load().aliases[iso2]
+}
+
+// FindHistorical finds a withdrawn country by its ISO 3166-3 code or its former alpha-2,
+// alpha-3 or numeric code. Some former codes were reassigned (e.g. BY), so callers
+// try FindByCode first.
+func (r *countryRepository) FindHistorical(code string) (*domain.Match, error) {
+	idx := r.index.Load()
+
+	normalized := r.normalizer.Normalize(code)
+	if numeric, ok := domain.NumericCode(normalized); ok {
+		normalized = numeric
 	}
 
-	// Load aliases
-	aliases, err := loader.LoadAliases()
-	if err != nil {
-		return fmt.Errorf("failed to load aliases: %w", err)
+	var keys []targetKey[*historicalEntry]
+	for _, hk := range idx.historical[normalized] {
+		if hasCodeOrigin(hk.origins) {
+			keys = append(keys, hk)
+		}
+	}
+
+	switch len(keys) {
+	case 0:
+		return nil, domain.NewNotFoundError(code)
+	case 1:
+		match := idx.newHistoricalMatch(normalized, keys[0])
+		match.NormalizedQuery = normalized
+		return match, nil
+	default:
+		return nil, domain.NewAmbiguousError(code, historicalCountries(keys))
+	}
+}
+
+// MatchSubdivision resolves the name, alias or code of a subdivision (ISO 3166-2) to the
+// subdivision and its country. Codes match with or without the country prefix ("US-CA",
+// "CA"); a non-empty country (ISO2) restricts the candidates to its subdivisions.
+func (r *countryRepository) MatchSubdivision(query, country string) (*domain.Match, error) {
+	idx := r.index.Load()
+
+	normalized := r.normalizer.Normalize(query)
+	var keys []targetKey[*domain.Subdivision]
+	for _, sk := range idx.subdivisions[normalized] {
+		if country == "" || sk.target.Country == country {
+			keys = append(keys, sk)
+		}
+	}
+
+	switch len(keys) {
+	case 0:
+		return nil, domain.NewSubdivisionNotFoundError(query)
+	case 1:
+		match := idx.newSubdivisionMatch(normalized, keys[0])
+		match.NormalizedQuery = normalized
+		return match, nil
+	default:
+		return nil, domain.NewAmbiguousSubdivisionError(query, subdivisionsOf(keys))
+	}
+}
+
+// Subdivisions returns the subdivisions of a country, sorted by code
+func (r *countryRepository) Subdivisions(country string) []*domain.Subdivision {
+	return r.index.Load().bySubdivided[country]
+}
+
+// PostalCodeFormats returns the postal code formats of a country, in load order
+func (r *countryRepository) PostalCodeFormats(country string) []*domain.PostalCodeFormat {
+	return r.index.Load().postalCodes[country]
+}
+
+// PostalCodeCountries returns every country with a postal code format matching code,
+// sorted by ISO2
+func (r *countryRepository) PostalCodeCountries(code string) []*domain.Country {
+	idx := r.index.Load()
+
+	var countries []*domain.Country
+	for _, country := range idx.list {
+		for _, format := range idx.postalCodes[country.ISO2] {
+			if format.Matches(code) {
+				countries = append(countries, country)
+				break
+			}
+		}
+	}
+	return countries
+}
+
+// subdivisionFallback resolves a subdivision key to the country it belongs to. The match
+// names the subdivision when only one has the key; keys of subdivisions in several
+// countries (e.g. "NT") are ambiguous between those countries.
+func (idx *countryIndex) subdivisionFallback(query, normalized string, keys []targetKey[*domain.Subdivision]) (*domain.Match, error) {
+	var countries []*domain.Country
+	for _, sk := range keys {
+		country := idx.codeToCountry[sk.target.Country]
+		if len(countries) == 0 || countries[len(countries)-1] != country {
+			countries = append(countries, country)
+		}
+	}
+	if len(countries) > 1 {
+		return nil, domain.NewAmbiguousError(query, countries)
+	}
+
+	match := &domain.Match{
+		Country:     countries[0],
+		Type:        domain.MatchTypeSubdivision,
+		MatchedName: normalized,
+		Score:       scoreSubdivision,
+	}
+	for _, sk := range keys {
+		match.Provenance = append(match.Provenance, sk.origins...)
+	}
+	if len(keys) == 1 {
+		match.Subdivision = keys[0].target
+	}
+
+	return match, nil
+}
+
+// hasCodeOrigin reports whether any origin is a code rather than a name or alias
+func hasCodeOrigin(origins []domain.Provenance) bool {
+	for _, origin := range origins {
+		switch origin.Source {
+		case domain.MatchSourceHistoricalCode, domain.MatchSourceISO2, domain.MatchSourceISO3, domain.MatchSourceNumeric:
+			return true
+		}
+	}
+	return false
+}
+
+// FindByCodeIn returns every country with code in a code system (see domain.CodeSystems),
+// sorted by ISO2. The code must be in the system's canonical form (see domain.NormalizeCode).
+// Some systems share codes between countries, so there can be several.
+func (r *countryRepository) FindByCodeIn(system, code string) []*domain.Country {
+	return r.index.Load().systems[system][code]
+}
+
+// Suggest returns up to limit candidate countries for a query, best first.
+// Every country appears at most once, with its highest scoring match.
+func (r *countryRepository) Suggest(name string, limit int) []*domain.Match {
+	normalized := r.normalizer.Normalize(name)
+	if normalized == "" || limit <= 0 {
+		return nil
+	}
+
+	idx := r.index.Load()
+	length := len([]rune(normalized))
+	fuzzyEnabled := r.matching.FuzzyEnabled && length >= r.matching.FuzzyMinLength
+
+	best := make(map[string]*domain.Match)
+	consider := func(match *domain.Match) {
+		current, exists := best[match.Country.ISO2]
+		if !exists || match.Score > current.Score ||
+			(match.Score == current.Score && match.MatchedName < current.MatchedName) {
+			best[match.Country.ISO2] = match
+		}
+	}
+
+	idx.forEachEntry(func(key string, entry indexEntry) {
+		if key == normalized {
+			consider(idx.newMatch(key, entry))
+			return
+		}
+
+		keyLength := len([]rune(key))
+		if length >= minPrefixLength && strings.HasPrefix(key, normalized) {
+			match := idx.newMatch(key, entry)
+			match.Type = domain.MatchTypePrefix
+			match.Score = roundScore(scorePrefixBase + scorePrefixRange*float64(length)/float64(keyLength))
+			consider(match)
+			return
+		}
+
+		if !fuzzyEnabled || keyLength < r.matching.FuzzyMinLength {
+			return
+		}
+
+		if distance, ok := fuzzy.WithinDistance(normalized, key, r.matching.FuzzyMaxDistance); ok {
+			match := idx.newMatch(key, entry)
+			match.Type = domain.MatchTypeFuzzy
+			match.Distance = distance
+			match.Score = roundScore(scoreFuzzyMax * (1 - float64(distance)/float64(max(length, keyLength))))
+			consider(match)
+		}
+	})
+
+	matches := make([]*domain.Match, 0, len(best))
+	for _, match := range best {
+		matches = append(matches, match)
 	}
 
-	// Build country code map and add all names to lookup
-	for i := range countries {
-		country := &countries[i]
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Country.ISO2 < matches[j].Country.ISO2
+	})
 
-		// Store by both ISO2 and ISO3 codes
-		r.codeToCountry[country.ISO2] = country
-		r.codeToCountry[country.ISO3] = country
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
 
-		// Add all multilingual names to lookup map
-		for _, name := range country.Names {
-			normalized := r.normalizer.Normalize(name)
-			r.nameToCode[normalized] = country.ISO2
+// Autocomplete returns up to limit countries with a name, alias or code starting with prefix.
+// Countries whose key equals the prefix come first, then names before aliases and codes,
+// then shorter keys; each country appears once.
+func (r *countryRepository) Autocomplete(prefix string, limit int) []*domain.Match {
+	normalized := r.normalizer.Normalize(prefix)
+	if normalized == "" || limit <= 0 {
+		return nil
+	}
+
+	idx := r.index.Load()
+	best := make(map[string]*domain.Match)
+	for _, candidate := range idx.prefixes.search(normalized) {
+		match := idx.newMatch(candidate.key, candidate.entry)
+		if candidate.key != normalized {
+			match.Type = domain.MatchTypePrefix
 		}
 
-		// Add ISO codes themselves as lookup keys
-		r.nameToCode[r.normalizer.Normalize(country.ISO2)] = country.ISO2
-		r.nameToCode[r.normalizer.Normalize(country.ISO3)] = country.ISO2
+		current, exists := best[candidate.entry.code]
+		if !exists || autocompleteLess(match, current) {
+			best[candidate.entry.code] = match
+		}
 	}
 
-	// Build alias lookup map
-	for isoCode, aliasNames := range aliases {
-		for _, alias := range aliasNames {
-			normalized := r.normalizer.Normalize(alias)
-			r.nameToCode[normalized] = isoCode
+	matches := make([]*domain.Match, 0, len(best))
+	for _, match := range best {
+		matches = append(matches, match)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return autocompleteLess(matches[i], matches[j])
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// autocompleteLess orders autocomplete candidates: complete keys first, then by score
+// (names before aliases before codes), then by key length and finally alphabetically
+func autocompleteLess(a, b *domain.Match) bool {
+	aComplete, bComplete := a.Type != domain.MatchTypePrefix, b.Type != domain.MatchTypePrefix
+	if aComplete != bComplete {
+		return aComplete
+	}
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	if len(a.MatchedName) != len(b.MatchedName) {
+		return len(a.MatchedName) < len(b.MatchedName)
+	}
+	if a.MatchedName != b.MatchedName {
+		return a.MatchedName < b.MatchedName
+	}
+	return a.Country.ISO2 < b.Country.ISO2
+}
+
+// fuzzyMatch looks for the index key with the smallest edit distance to the normalized query.
+// It returns nil when fuzzy matching is disabled, the query is too short or nothing is close
+// enough, and an ambiguous error when the closest keys belong to different countries.
+func (r *countryRepository) fuzzyMatch(idx *countryIndex, query, normalized string) (*domain.Match, error) {
+	if !r.matching.FuzzyEnabled {
+		return nil, nil
+	}
+
+	length := len([]rune(normalized))
+	if length < r.matching.FuzzyMinLength {
+		return nil, nil
+	}
+
+	// Allow roughly one edit per four characters, capped by the configured threshold
+	maxDistance := min(r.matching.FuzzyMaxDistance, max(1, length/4))
+
+	bestDistance := maxDistance + 1
+	var best []indexEntry
+	var bestKey string
+	idx.forEachEntry(func(key string, entry indexEntry) {
+		if len([]rune(key)) < r.matching.FuzzyMinLength {
+			return // Short keys are mostly ISO codes and match far too much
+		}
+
+		distance, ok := fuzzy.WithinDistance(normalized, key, maxDistance)
+		if !ok {
+			return
+		}
+
+		switch {
+		case distance < bestDistance:
+			bestDistance = distance
+			best = []indexEntry{entry}
+			bestKey = key
+		case distance == bestDistance:
+			best = append(best, entry)
+			bestKey = min(bestKey, key)
 		}
+	})
+
+	if len(best) == 0 {
+		return nil, nil
 	}
 
-	return nil
+	// Refuse to guess when equally close keys point at different countries
+	distinct := uniqueByCode(best)
+	if len(distinct) > 1 {
+		return nil, domain.NewAmbiguousError(query, idx.countriesFor(distinct))
+	}
+
+	return &domain.Match{
+		Country:     idx.codeToCountry[distinct[0].code],
+		Type:        domain.MatchTypeFuzzy,
+		MatchedName: bestKey,
+		Provenance:  idx.nameToCode[bestKey].origins,
+		Distance:    bestDistance,
+		Score:       roundScore(scoreFuzzyMax * (1 - float64(bestDistance)/float64(length))),
+	}, nil
+}
+
+// roundScore keeps scores readable in API responses
+func roundScore(score float64) float64 {
+	return math.Round(score*1000) / 1000
 }
@@ -0,0 +1,796 @@
+package memory_test
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"country-iso-matcher/src/internal/config"
+	"country-iso-matcher/src/internal/data"
+	"country-iso-matcher/src/internal/domain"
+	"country-iso-matcher/src/internal/repository/memory"
+	"country-iso-matcher/src/pkg/normalizer"
+)
+
+func TestCountryRepository_MatchByName(t *testing.T) {
+	matching := config.DefaultConfig().Matching
+	repo, err := memory.NewCountryRepository(normalizer.NewTextNormalizer(), data.NewMemoryLoader(), &matching)
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		query         string
+		expectedCode  string
+		expectedType  domain.MatchType
+		expectedError bool
+	}{
+		{
+			name:         "exact name",
+			query:        "Germany",
+			expectedCode: "DE",
+			expectedType: domain.MatchTypeExact,
+		},
+		{
+			name:         "transposed letters",
+			query:        "Germnay",
+			expectedCode: "DE",
+			expectedType: domain.MatchTypeFuzzy,
+		},
+		{
+			name:         "doubled letter",
+			query:        "Phillipines",
+			expectedCode: "PH",
+			expectedType: domain.MatchTypeFuzzy,
+		},
+		{
+			name:          "short query is never fuzzy matched",
+			query:         "frx",
+			expectedError: true,
+		},
+		{
+			name:          "too far from any name",
+			query:         "Atlantis",
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, err := repo.MatchByName(tt.query)
+
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("expected error but got match %s", match.Country.ISO2)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if match.Country.ISO2 != tt.expectedCode {
+				t.Errorf("expected ISO code %s, got %s", tt.expectedCode, match.Country.ISO2)
+			}
+
+			if match.Type != tt.expectedType {
+				t.Errorf("expected match type %s, got %s", tt.expectedType, match.Type)
+			}
+		})
+	}
+}
+
+func TestCountryRepository_FuzzyDisabled(t *testing.T) {
+	repo, err := memory.NewCountryRepository(normalizer.NewTextNormalizer(), data.NewMemoryLoader(), &config.MatchingConfig{})
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+
+	if _, err := repo.MatchByName("Germnay"); err == nil {
+		t.Errorf("expected no match with fuzzy matching disabled")
+	}
+}
+
+func TestCountryRepository_Suggest(t *testing.T) {
+	matching := config.DefaultConfig().Matching
+	repo, err := memory.NewCountryRepository(normalizer.NewTextNormalizer(), data.NewMemoryLoader(), &matching)
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+
+	suggestions := repo.Suggest("gu", 10)
+	if len(suggestions) == 0 {
+		t.Fatalf("expected prefix suggestions for 'gu'")
+	}
+
+	seen := make(map[string]bool)
+	for i, match := range suggestions {
+		if seen[match.Country.ISO2] {
+			t.Errorf("country %s suggested more than once", match.Country.ISO2)
+		}
+		seen[match.Country.ISO2] = true
+
+		if i > 0 && match.Score > suggestions[i-1].Score {
+			t.Errorf("suggestions not sorted by score at position %d", i)
+		}
+	}
+
+	top := repo.Suggest("Germany", 3)
+	if len(top) == 0 || top[0].Country.ISO2 != "DE" || top[0].Type != domain.MatchTypeExact {
+		t.Errorf("expected exact match for Germany to rank first")
+	}
+}
+
+type stubLoader struct {
+	countries []domain.Country
+	aliases   map[string][]string
+}
+
+func (l *stubLoader) LoadCountries() ([]domain.Country, error) { return l.countries, nil }
+
+func (l *stubLoader) LoadAliases() (map[string][]string, error) { return l.aliases, nil }
+
+func TestCountryRepository_AmbiguousNames(t *testing.T) {
+	loader := &stubLoader{
+		countries: []domain.Country{
+			{ISO2: "CG", ISO3: "COG", Names: map[string]string{"en": "Congo"}},
+			{ISO2: "CD", ISO3: "COD", Names: map[string]string{"en": "Democratic Republic of the Congo"}},
+		},
+		aliases: map[string][]string{
+			"CD": {"congo", "drc"},
+		},
+	}
+
+	matching := config.DefaultConfig().Matching
+	repo, err := memory.NewCountryRepository(normalizer.NewTextNormalizer(), loader, &matching)
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+
+	_, err = repo.MatchByName("Congo")
+	appErr, ok := err.(*domain.AppError)
+	if !ok || appErr.Code != 409 {
+		t.Fatalf("expected ambiguous error, got %v", err)
+	}
+	if len(appErr.Candidates) != 2 || appErr.Candidates[0].ISO2Code != "CD" || appErr.Candidates[1].ISO2Code != "CG" {
+		t.Errorf("unexpected candidates: %+v", appErr.Candidates)
+	}
+
+	if match, err := repo.MatchByName("drc"); err != nil || match.Country.ISO2 != "CD" {
+		t.Errorf("expected unambiguous alias to resolve to CD, got %v", err)
+	}
+
+	collisions := repo.Collisions()
+	if len(collisions) != 1 || len(collisions["congo"]) != 2 {
+		t.Errorf("unexpected collisions: %v", collisions)
+	}
+
+	extractions := repo.Extract("From the Congo to the Democratic Republic of the Congo")
+	if len(extractions) != 1 || extractions[0].Match.Country.ISO2 != "CD" || extractions[0].Start != 22 {
+		t.Errorf("expected only the unambiguous name to be extracted, got %+v", extractions)
+	}
+}
+
+func TestCountryRepository_Autocomplete(t *testing.T) {
+	matching := config.DefaultConfig().Matching
+	repo, err := memory.NewCountryRepository(normalizer.NewTextNormalizer(), data.NewMemoryLoader(), &matching)
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+
+	results := repo.Autocomplete("Rom", 5)
+	if len(results) != 1 || results[0].Country.ISO2 != "RO" {
+		t.Fatalf("expected only Romania for 'Rom', got %d results", len(results))
+	}
+
+	results = repo.Autocomplete("ch", 3)
+	if len(results) != 3 {
+		t.Fatalf("expected limit of 3 results, got %d", len(results))
+	}
+	if results[0].Country.ISO2 != "CH" || results[0].Type != domain.MatchTypeCode {
+		t.Errorf("expected the complete code 'ch' to rank first, got %s (%s)", results[0].Country.ISO2, results[0].Type)
+	}
+
+	if results := repo.Autocomplete("zzz", 5); len(results) != 0 {
+		t.Errorf("expected no results for unknown prefix, got %d", len(results))
+	}
+}
+
+func TestCountryRepository_Provenance(t *testing.T) {
+	loader := &stubLoader{
+		countries: []domain.Country{
+			{ISO2: "DE", ISO3: "DEU", Names: map[string]string{"en": "Germany", "fr": "Allemagne"}},
+		},
+		aliases: map[string][]string{
+			"DE": {"allemagne", "deutschland"},
+		},
+	}
+
+	matching := config.DefaultConfig().Matching
+	repo, err := memory.NewCountryRepository(normalizer.NewTextNormalizer(), loader, &matching)
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+
+	match, err := repo.MatchByName("ALLEMAGNE")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []domain.Provenance{
+		{Source: domain.MatchSourceName, Language: "fr", Original: "Allemagne"},
+		{Source: domain.MatchSourceAlias, Original: "allemagne"},
+	}
+	if len(match.Provenance) != len(expected) {
+		t.Fatalf("expected %d provenance entries, got %+v", len(expected), match.Provenance)
+	}
+	for i := range expected {
+		if match.Provenance[i] != expected[i] {
+			t.Errorf("provenance %d: expected %+v, got %+v", i, expected[i], match.Provenance[i])
+		}
+	}
+	if match.Type != domain.MatchTypeExact {
+		t.Errorf("expected the name to decide the match type, got %s", match.Type)
+	}
+
+	match, err = repo.MatchByName("deu")
+	if err != nil || match.Type != domain.MatchTypeCode || match.Provenance[0].Source != domain.MatchSourceISO3 {
+		t.Errorf("expected ISO3 provenance for 'deu', got %+v (%v)", match, err)
+	}
+}
+
+func TestCountryRepository_Reload(t *testing.T) {
+	loader := &stubLoader{
+		countries: []domain.Country{
+			{ISO2: "DE", ISO3: "DEU", Names: map[string]string{"en": "Germany"}},
+		},
+	}
+
+	matching := config.DefaultConfig().Matching
+	repo, err := memory.NewCountryRepository(normalizer.NewTextNormalizer(), loader, &matching)
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+
+	loader.countries = []domain.Country{
+		{ISO2: "DE", ISO3: "DEU", Names: map[string]string{"en": "Germany"}},
+		{ISO2: "FR", ISO3: "FRA", Names: map[string]string{"en": "France"}},
+	}
+	if count, err := repo.Reload(); err != nil || count != 2 {
+		t.Fatalf("expected reload of 2 countries, got %d, %v", count, err)
+	}
+	if match, err := repo.MatchByName("France"); err != nil || match.Country.ISO2 != "FR" {
+		t.Errorf("expected reloaded data to resolve France, got %v", err)
+	}
+
+	// Invalid data must leave the current index in place
+	loader.countries = []domain.Country{
+		{ISO2: "FR", ISO3: "FRA", Names: map[string]string{"en": "France"}},
+		{ISO2: "FR", ISO3: "FRX", Names: map[string]string{"en": "Duplicate"}},
+	}
+	if _, err := repo.Reload(); err == nil {
+		t.Fatal("expected reload with duplicate codes to fail")
+	}
+	if match, err := repo.MatchByName("Germany"); err != nil || match.Country.ISO2 != "DE" {
+		t.Errorf("expected previous data after failed reload, got %v", err)
+	}
+}
+
+func TestCountryRepository_MissingISO3(t *testing.T) {
+	loader := &stubLoader{
+		countries: []domain.Country{
+			{ISO2: "DE", ISO3: "DEU", Names: map[string]string{"en": "Germany"}},
+			{ISO2: "RO", Names: map[string]string{"en": "Romania"}},
+		},
+	}
+
+	matching := config.DefaultConfig().Matching
+	repo, err := memory.NewCountryRepository(normalizer.NewTextNormalizer(), loader, &matching)
+	if err != nil {
+		t.Fatalf("expected countries without ISO3 to load, got %v", err)
+	}
+
+	country, err := repo.FindByCode("RO")
+	if err != nil || country.ISO3 != "" {
+		t.Fatalf("expected Romania without ISO3, got %+v (%v)", country, err)
+	}
+	match, err := repo.MatchByName("ro")
+	if err != nil || match.Provenance[0].Source != domain.MatchSourceISO2 {
+		t.Errorf("expected ISO2 provenance for 'ro', got %+v (%v)", match, err)
+	}
+	if _, err := repo.FindByCode(""); err == nil {
+		t.Error("expected no country for an empty code")
+	}
+}
+
+func TestCountryRepository_NumericCodes(t *testing.T) {
+	matching := config.DefaultConfig().Matching
+	repo, err := memory.NewCountryRepository(normalizer.NewTextNormalizer(), data.NewEmbeddedLoader(), &matching)
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+
+	tests := []struct {
+		code     string
+		iso2     string
+		iso3     string
+		numeric  string
+		notFound bool
+	}{
+		{code: "642", iso2: "RO", iso3: "ROU", numeric: "642"},
+		{code: "076", iso2: "BR", iso3: "BRA", numeric: "076"},
+		{code: "76", iso2: "BR", iso3: "BRA", numeric: "076"},
+		{code: "0004", iso2: "AF", iso3: "AFG", numeric: "004"},
+		{code: "999", notFound: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			byCode, err := repo.FindByCode(tt.code)
+			if tt.notFound {
+				if err == nil {
+					t.Fatalf("expected %s not to be found, got %s", tt.code, byCode.ISO2)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindByCode(%q): %v", tt.code, err)
+			}
+			if byCode.ISO2 != tt.iso2 || byCode.ISO3 != tt.iso3 || byCode.Numeric != tt.numeric {
+				t.Errorf("FindByCode(%q) = %s/%s/%s", tt.code, byCode.ISO2, byCode.ISO3, byCode.Numeric)
+			}
+
+			match, err := repo.MatchByName(tt.code)
+			if err != nil {
+				t.Fatalf("MatchByName(%q): %v", tt.code, err)
+			}
+			if match.Country.ISO2 != tt.iso2 || match.Type != domain.MatchTypeCode {
+				t.Errorf("MatchByName(%q) = %s (%s)", tt.code, match.Country.ISO2, match.Type)
+			}
+			if match.Provenance[0].Source != domain.MatchSourceNumeric {
+				t.Errorf("expected numeric provenance, got %+v", match.Provenance)
+			}
+
+			// Every code system converts back to the same country
+			for _, code := range []string{byCode.ISO2, byCode.ISO3, byCode.Numeric} {
+				if back, err := repo.FindByCode(code); err != nil || back.ISO2 != tt.iso2 {
+					t.Errorf("FindByCode(%q) does not round-trip to %s: %v", code, tt.iso2, err)
+				}
+			}
+		})
+	}
+}
+
+func TestCountryRepository_FindByCodeIn(t *testing.T) {
+	matching := config.DefaultConfig().Matching
+	repo, err := memory.NewCountryRepository(normalizer.NewTextNormalizer(), data.NewEmbeddedLoader(), &matching)
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+
+	tests := []struct {
+		system, code string
+		expected     []string
+	}{
+		{system: domain.CodeSystemIOC, code: "GER", expected: []string{"DE"}},
+		{system: domain.CodeSystemFIFA, code: "SUI", expected: []string{"CH"}},
+		{system: domain.CodeSystemFIPS, code: "GM", expected: []string{"DE"}},
+		{system: domain.CodeSystemTLD, code: ".uk", expected: []string{"GB"}},
+		{system: domain.CodeSystemVehicle, code: "D", expected: []string{"DE"}},
+		{system: domain.CodeSystemM49, code: "642", expected: []string{"RO"}},
+		{system: domain.CodeSystemITU, code: "7", expected: []string{"KZ", "RU"}},
+		{system: domain.CodeSystemIOC, code: "DEU"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.system+"/"+tt.code, func(t *testing.T) {
+			var codes []string
+			for _, country := range repo.FindByCodeIn(tt.system, tt.code) {
+				codes = append(codes, country.ISO2)
+			}
+			if strings.Join(codes, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected %v, got %v", tt.expected, codes)
+			}
+		})
+	}
+}
+
+func TestCountryRepository_Historical(t *testing.T) {
+	matching := config.DefaultConfig().Matching
+	matching.HistoricalSuccessors = map[string]string{"ZRCD": "CD"}
+	loader := data.WithHistorical(data.NewEmbeddedLoader(), "")
+	repo, err := memory.NewCountryRepository(normalizer.NewTextNormalizer(), loader, &matching)
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+
+	tests := []struct {
+		query         string
+		expectedCode  string
+		expectedType  domain.MatchType
+		historical    string
+		successors    string
+		expectedError int
+	}{
+		{query: "USSR", expectedCode: "SU", expectedType: domain.MatchTypeHistorical, historical: "SUHH",
+			successors: "AM,AZ,BY,EE,GE,KG,KZ,LT,LV,MD,RU,TJ,TM,UA,UZ"},
+		{query: "East Germany", expectedCode: "DD", expectedType: domain.MatchTypeHistorical, historical: "DDDE", successors: "DE"},
+		{query: "Czechoslovakia", expectedCode: "CS", expectedType: domain.MatchTypeHistorical, historical: "CSHH", successors: "CZ,SK"},
+		{query: "yugoslavia", expectedCode: "YU", expectedType: domain.MatchTypeHistorical, historical: "YUCS", successors: "BA,HR,ME,MK,RS,SI"},
+		{query: "Zaïre", expectedCode: "CD", expectedType: domain.MatchTypeHistorical, historical: "ZRCD", successors: "CD"},
+		{query: "SUHH", expectedCode: "SU", expectedType: domain.MatchTypeHistorical, historical: "SUHH",
+			successors: "AM,AZ,BY,EE,GE,KG,KZ,LT,LV,MD,RU,TJ,TM,UA,UZ"},
+		{query: "Russia", expectedCode: "RU", expectedType: domain.MatchTypeAlias},
+		{query: "BY", expectedCode: "BY", expectedType: domain.MatchTypeCode},
+		{query: "CS", expectedError: 409},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			match, err := repo.MatchByName(tt.query)
+			if tt.expectedError != 0 {
+				appErr, ok := err.(*domain.AppError)
+				if !ok || appErr.Code != tt.expectedError {
+					t.Fatalf("expected error code %d, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if match.Country.ISO2 != tt.expectedCode || match.Type != tt.expectedType {
+				t.Errorf("expected %s (%s), got %s (%s)", tt.expectedCode, tt.expectedType, match.Country.ISO2, match.Type)
+			}
+			if tt.historical == "" {
+				if match.Historical != nil {
+					t.Errorf("expected no historical country, got %s", match.Historical.Code)
+				}
+				return
+			}
+			if match.Historical == nil || match.Historical.Code != tt.historical {
+				t.Fatalf("expected historical country %s, got %+v", tt.historical, match.Historical)
+			}
+			var successors []string
+			for _, country := range match.Successors {
+				successors = append(successors, country.ISO2)
+			}
+			if strings.Join(successors, ",") != tt.successors {
+				t.Errorf("expected successors %s, got %v", tt.successors, successors)
+			}
+		})
+	}
+
+	for code, expected := range map[string]string{"CSHH": "CSHH", "csxx": "CSXX", "810": "SUHH", "DDR": "DDDE"} {
+		match, err := repo.FindHistorical(code)
+		if err != nil || match.Historical.Code != expected {
+			t.Errorf("FindHistorical(%q) = %v, %v; expected %s", code, match, err, expected)
+		}
+	}
+	if _, err := repo.FindHistorical("USSR"); err == nil {
+		t.Error("expected FindHistorical to ignore names")
+	}
+
+	matching.HistoricalSuccessors = map[string]string{"XXHH": "RU"}
+	if _, err := memory.NewCountryRepository(normalizer.NewTextNormalizer(), loader, &matching); err == nil {
+		t.Error("expected an error for a successor of an unknown historical country")
+	}
+}
+
+func TestCountryRepository_Subdivisions(t *testing.T) {
+	matching := config.DefaultConfig().Matching
+	loader := data.WithSubdivisions(data.NewEmbeddedLoader(), "")
+	repo, err := memory.NewCountryRepository(normalizer.NewTextNormalizer(), loader, &matching)
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+
+	tests := []struct {
+		query         string
+		country       string
+		expectedCode  string
+		expectedType  domain.MatchType
+		expectedError int
+	}{
+		{query: "Bavaria", expectedCode: "DE-BY", expectedType: domain.MatchTypeExact},
+		{query: "Freistaat Bayern", expectedCode: "DE-BY", expectedType: domain.MatchTypeAlias},
+		{query: "us-ca", expectedCode: "US-CA", expectedType: domain.MatchTypeCode},
+		{query: "ON", expectedCode: "CA-ON", expectedType: domain.MatchTypeCode},
+		{query: "Québec", expectedCode: "CA-QC", expectedType: domain.MatchTypeExact},
+		{query: "CA", country: "US", expectedCode: "US-CA", expectedType: domain.MatchTypeCode},
+		{query: "NT", country: "AU", expectedCode: "AU-NT", expectedType: domain.MatchTypeCode},
+		{query: "NT", expectedError: 409},
+		{query: "Ontario", country: "US", expectedError: 404},
+		{query: "Atlantis", expectedError: 404},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query+"/"+tt.country, func(t *testing.T) {
+			match, err := repo.MatchSubdivision(tt.query, tt.country)
+			if tt.expectedError != 0 {
+				appErr, ok := err.(*domain.AppError)
+				if !ok || appErr.Code != tt.expectedError {
+					t.Fatalf("expected error code %d, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if match.Subdivision.Code != tt.expectedCode || match.Type != tt.expectedType {
+				t.Errorf("expected %s (%s), got %s (%s)", tt.expectedCode, tt.expectedType, match.Subdivision.Code, match.Type)
+			}
+			if match.Country.ISO2 != match.Subdivision.Country {
+				t.Errorf("expected country %s, got %s", match.Subdivision.Country, match.Country.ISO2)
+			}
+		})
+	}
+
+	subdivisions := repo.Subdivisions("CA")
+	if len(subdivisions) != 13 || subdivisions[0].Code != "CA-AB" || subdivisions[12].Code != "CA-YT" {
+		t.Errorf("expected the 13 provinces and territories of Canada, got %d", len(subdivisions))
+	}
+
+	match, err := repo.MatchByName("Bavaria")
+	if err == nil && match.Type == domain.MatchTypeSubdivision {
+		t.Error("expected no subdivision match with the fallback disabled")
+	}
+}
+
+func TestCountryRepository_SubdivisionFallback(t *testing.T) {
+	matching := config.DefaultConfig().Matching
+	matching.SubdivisionFallback = true
+	loader := data.WithSubdivisions(data.NewEmbeddedLoader(), "")
+	repo, err := memory.NewCountryRepository(normalizer.NewTextNormalizer(), loader, &matching)
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+
+	tests := []struct {
+		query         string
+		expectedCode  string
+		expectedType  domain.MatchType
+		subdivision   string
+		expectedError int
+	}{
+		{query: "Bavaria", expectedCode: "DE", expectedType: domain.MatchTypeSubdivision, subdivision: "DE-BY"},
+		{query: "California", expectedCode: "US", expectedType: domain.MatchTypeSubdivision, subdivision: "US-CA"},
+		{query: "Georgia", expectedCode: "GE", expectedType: domain.MatchTypeExact},
+		{query: "CA", expectedCode: "CA", expectedType: domain.MatchTypeCode},
+		{query: "NT", expectedCode: "NT", expectedType: domain.MatchTypeHistorical}, // The Neutral Zone
+		{query: "WA", expectedError: 409}, // Western Australia or Washington
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			match, err := repo.MatchByName(tt.query)
+			if tt.expectedError != 0 {
+				appErr, ok := err.(*domain.AppError)
+				if !ok || appErr.Code != tt.expectedError {
+					t.Fatalf("expected error code %d, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if match.Country.ISO2 != tt.expectedCode || match.Type != tt.expectedType {
+				t.Errorf("expected %s (%s), got %s (%s)", tt.expectedCode, tt.expectedType, match.Country.ISO2, match.Type)
+			}
+			if tt.subdivision == "" {
+				if match.Subdivision != nil {
+					t.Errorf("expected no subdivision, got %s", match.Subdivision.Code)
+				}
+				return
+			}
+			if match.Subdivision == nil || match.Subdivision.Code != tt.subdivision {
+				t.Errorf("expected subdivision %s, got %+v", tt.subdivision, match.Subdivision)
+			}
+		})
+	}
+}
+
+func TestCountryRepository_Extract(t *testing.T) {
+	matching := config.DefaultConfig().Matching
+	loader := data.WithSubdivisions(data.NewEmbeddedLoader(), "")
+	repo, err := memory.NewCountryRepository(normalizer.NewTextNormalizer(), loader, &matching)
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+
+	// Each mention is written as text@start-end=ISO2
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			name:     "name in a sentence",
+			text:     "Shipped from Hamburg, Germany to São Paulo",
+			expected: []string{"Germany@22-29=DE"},
+		},
+		{
+			name:     "code in an address, postal code ignored",
+			text:     "Bucharest RO 010011",
+			expected: []string{"RO@10-12=RO"},
+		},
+		{
+			name:     "offsets count characters",
+			text:     "Öl aus Österreich und Côte d'Ivoire",
+			expected: []string{"Österreich@7-17=AT", "Côte d'Ivoire@22-35=CI"},
+		},
+		{
+			name:     "longest window wins",
+			text:     "Flights to South Africa and Guinea-Bissau",
+			expected: []string{"South Africa@11-23=ZA", "Guinea-Bissau@28-41=GW"},
+		},
+		{
+			name:     "alias with dots",
+			text:     "Made in the U.S.A. and the U.K.",
+			expected: []string{"U.S.A.@12-18=US", "U.K.@27-31=GB"},
+		},
+		{
+			name:     "lower-case codes and common words are ignored",
+			text:     "tell it to us in person, or at home",
+			expected: nil,
+		},
+		{
+			name:     "capitalized common word codes in mixed-case text",
+			text:     "Offices in the US and IT",
+			expected: []string{"US@15-17=US", "IT@22-24=IT"},
+		},
+		{
+			name:     "common word codes in all capitals text",
+			text:     "SHIPPED TO US IN BOXES FROM FRANCE",
+			expected: []string{"FRANCE@28-34=FR"},
+		},
+		{
+			name:     "historical country",
+			text:     "Born in the USSR",
+			expected: []string{"USSR@12-16=SU"},
+		},
+		{
+			name:     "subdivisions without the fallback",
+			text:     "Munich, Bavaria",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mentions []string
+			for _, extraction := range repo.Extract(tt.text) {
+				mentions = append(mentions, fmt.Sprintf("%s@%d-%d=%s", extraction.Text, extraction.Start, extraction.End, extraction.Match.Country.ISO2))
+			}
+			if strings.Join(mentions, " ") != strings.Join(tt.expected, " ") {
+				t.Errorf("expected %v, got %v", tt.expected, mentions)
+			}
+		})
+	}
+
+	extractions := repo.Extract("Roast turkey from Turkey, AT the IN price")
+	if len(extractions) != 4 {
+		t.Fatalf("expected 4 mentions, got %d", len(extractions))
+	}
+	for i, confidence := range []float64{0.75, 1, 0.5, 0.5} {
+		if extractions[i].Match.Score != confidence {
+			t.Errorf("expected confidence %v for %q, got %v", confidence, extractions[i].Text, extractions[i].Match.Score)
+		}
+	}
+
+	matching.SubdivisionFallback = true
+	repo, err = memory.NewCountryRepository(normalizer.NewTextNormalizer(), loader, &matching)
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+	extractions = repo.Extract("Munich, Bavaria (DE-BY, not NW)")
+	if len(extractions) != 2 || extractions[0].Match.Subdivision == nil || extractions[0].Match.Subdivision.Code != "DE-BY" ||
+		extractions[0].Match.Type != domain.MatchTypeSubdivision || extractions[1].Text != "DE-BY" {
+		t.Errorf("expected Bavaria and DE-BY as subdivision mentions, got %+v", extractions)
+	}
+}
+
+func TestCountryRepository_PostalCodes(t *testing.T) {
+	matching := config.DefaultConfig().Matching
+	loader := data.WithPostalCodes(data.NewEmbeddedLoader(), "")
+	repo, err := memory.NewCountryRepository(normalizer.NewTextNormalizer(), loader, &matching)
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+
+	if formats := repo.PostalCodeFormats("RO"); len(formats) != 1 || formats[0].Pattern != `\d{6}` {
+		t.Errorf("expected the format \\d{6} for RO, got %+v", formats)
+	}
+	if formats := repo.PostalCodeFormats("XX"); len(formats) != 0 {
+		t.Errorf("expected no formats for an unknown country, got %+v", formats)
+	}
+
+	tests := []struct {
+		code     string
+		expected []string
+	}{
+		{code: "k1a 0b1", expected: []string{"CA"}},
+		{code: "SW1A 1AA", expected: []string{"GB"}},
+		{code: "1012 JS", expected: []string{"NL"}},
+		{code: "HELLO", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			var codes []string
+			for _, country := range repo.PostalCodeCountries(tt.code) {
+				codes = append(codes, country.ISO2)
+			}
+			if !slices.Equal(codes, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, codes)
+			}
+		})
+	}
+
+	countries := repo.PostalCodeCountries("10115")
+	if len(countries) < 2 || !slices.IsSortedFunc(countries, func(a, b *domain.Country) int { return strings.Compare(a.ISO2, b.ISO2) }) {
+		t.Errorf("expected several countries in ISO2 order for a five-digit code, got %d", len(countries))
+	}
+}
+
+func TestCountryRepository_Demonyms(t *testing.T) {
+	matching := config.DefaultConfig().Matching
+	matching.FuzzyEnabled = false // "Germans" would otherwise find Germany
+	repo, err := memory.NewCountryRepository(normalizer.NewTextNormalizer(), data.NewEmbeddedLoader(), &matching)
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+
+	tests := []struct {
+		query           string
+		expectedCode    string
+		expectedForms   int
+		expectedOrigins int // Equals expectedForms when zero
+		expectedError   int
+	}{
+		{query: "German", expectedCode: "DE", expectedForms: 1},
+		{query: "romanian", expectedCode: "RO", expectedForms: 1},
+		{query: "Brazilians", expectedCode: "BR", expectedForms: 1},
+		{query: "Deutsch", expectedCode: "DE", expectedForms: 1},
+		{query: "Deutsche", expectedCode: "DE", expectedForms: 2, expectedOrigins: 1}, // Feminine and plural
+		{query: "FRANCAISE", expectedCode: "FR", expectedForms: 1},
+		{query: "Dominican", expectedError: 409},
+		{query: "Germany", expectedError: 404},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			match, err := repo.MatchDemonym(tt.query)
+			if tt.expectedError != 0 {
+				appErr, ok := err.(*domain.AppError)
+				if !ok || appErr.Code != tt.expectedError {
+					t.Fatalf("expected error code %d, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if match.Country.ISO2 != tt.expectedCode || match.Type != domain.MatchTypeDemonym {
+				t.Errorf("expected %s (demonym), got %s (%s)", tt.expectedCode, match.Country.ISO2, match.Type)
+			}
+			origins := tt.expectedOrigins
+			if origins == 0 {
+				origins = tt.expectedForms
+			}
+			if len(match.Demonyms) != tt.expectedForms || len(match.Provenance) != origins {
+				t.Errorf("expected %d forms from %d origins, got %+v and %+v", tt.expectedForms, origins, match.Demonyms, match.Provenance)
+			}
+			if match.Provenance[0].Source != domain.MatchSourceDemonym {
+				t.Errorf("expected demonym provenance, got %+v", match.Provenance)
+			}
+		})
+	}
+
+	// Demonyms stay out of the name index
+	if _, err := repo.MatchByName("Germans"); err == nil {
+		t.Error("expected demonyms not to match names")
+	}
+}
@@ -0,0 +1,231 @@
+package memory
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"country-iso-matcher/src/internal/domain"
+)
+
+const (
+	// scoreLowerCaseFactor scales the confidence of names written in lower case in a text
+	// that uses capitals elsewhere, e.g. "turkey" in "Roast turkey with chestnuts"
+	scoreLowerCaseFactor = 0.75
+	// scoreCommonWord is the confidence of codes that are also common English words, e.g. "IN"
+	scoreCommonWord = 0.5
+
+	// maxShortAliasLength is the longest alias that is treated like a code in free text, e.g. "usa"
+	maxShortAliasLength = 3
+)
+
+// commonWords are codes and short aliases that double as common English words. In free
+// text they only count when written in capitals in a text that is not all capitals.
+var commonWords = map[string]bool{
+	"am": true, "as": true, "at": true, "be": true, "by": true, "do": true, "id": true, "in": true,
+	"is": true, "it": true, "me": true, "my": true, "no": true, "so": true, "to": true, "us": true,
+	"and": true, "are": true, "arm": true, "ben": true, "bra": true, "can": true, "cod": true,
+	"com": true, "cub": true, "dom": true, "gin": true, "gum": true, "lie": true, "mac": true,
+	"mar": true, "nor": true, "pan": true, "per": true, "ton": true,
+}
+
+// token is a word of a free text: a run of letters, digits and marks that may contain
+// apostrophes, hyphens and dots between them, e.g. "d'Ivoire", "Guinea-Bissau" or "U.S"
+type token struct {
+	start, end int // Byte offsets in the text
+}
+
+// extractRules carries what Extract knows about the text as a whole
+type extractRules struct {
+	lowerCase    bool // The text has lower-case letters, so capitals are deliberate
+	upperCase    bool // The text has upper-case letters, so lower case is deliberate
+	subdivisions bool // Subdivision names count as mentions of their country
+}
+
+// Extract finds the countries mentioned in a free text, in order of appearance. Windows of
+// words, up to the longest key of the index, are looked up like MatchByName without the
+// fuzzy fallback; the longest matching window at a word wins and consumes its words.
+// To avoid false positives, codes and aliases of up to three letters only count when
+// written in capitals, and those that are also common words (e.g. "IN", "US") not at all
+// in texts written entirely in capitals. Numeric codes and names shared by several
+// countries are never reported. Subdivision names count when the subdivision fallback is enabled.
+func (r *countryRepository) Extract(text string) []domain.Extraction {
+	idx := r.index.Load()
+	rules := extractRules{
+		lowerCase:    strings.ToUpper(text) != text,
+		upperCase:    strings.ToLower(text) != text,
+		subdivisions: r.matching.SubdivisionFallback,
+	}
+
+	var extractions []domain.Extraction
+	tokens := tokenize(text)
+	offset, characters := 0, 0 // Byte offset and character offset after the previous mention
+	for i := 0; i < len(tokens); {
+		words, end, match := idx.matchWindow(text, tokens[i:], rules)
+		if match == nil {
+			i++
+			continue
+		}
+
+		start := tokens[i].start
+		characters += utf8.RuneCountInString(text[offset:start])
+		length := utf8.RuneCountInString(text[start:end])
+		extractions = append(extractions, domain.Extraction{
+			Text:  text[start:end],
+			Start: characters,
+			End:   characters + length,
+			Match: match,
+		})
+
+		offset, characters = end, characters+length
+		i += words
+	}
+
+	return extractions
+}
+
+// matchWindow matches the longest window of words at the start of tokens. It returns the
+// number of words the window spans, the byte offset where it ends and its match.
+func (idx *countryIndex) matchWindow(text string, tokens []token, rules extractRules) (int, int, *domain.Match) {
+	for words := min(idx.maxWords, len(tokens)); words > 0; words-- {
+		start, end := tokens[0].start, tokens[words-1].end
+
+		// A dot after the window may belong to the key, as in "U.S."
+		if strings.HasPrefix(text[end:], ".") {
+			if match := idx.matchSpan(text[start:end+1], rules); match != nil {
+				return words, end + 1, match
+			}
+		}
+		if match := idx.matchSpan(text[start:end], rules); match != nil {
+			return words, end, match
+		}
+	}
+	return 0, 0, nil
+}
+
+// matchSpan looks up a span of text and applies the rules against false positives.
+// It returns nil when the span is no mention.
+func (idx *countryIndex) matchSpan(span string, rules extractRules) *domain.Match {
+	key := idx.normalizer.Normalize(strings.Join(strings.Fields(span), " "))
+	if _, ambiguous := idx.ambiguous[key]; ambiguous {
+		return nil
+	}
+
+	var match *domain.Match
+	if entry, exists := idx.nameToCode[key]; exists {
+		match = idx.newMatch(key, entry)
+	} else if keys, exists := idx.historical[key]; exists && len(keys) == 1 {
+		match = idx.newHistoricalMatch(key, keys[0])
+	} else if keys, exists := idx.subdivisions[key]; exists && len(keys) == 1 && rules.subdivisions {
+		match = idx.newSubdivisionMatch(key, keys[0])
+		match.Type, match.Score = domain.MatchTypeSubdivision, scoreSubdivision
+	} else {
+		return nil
+	}
+
+	switch origin := match.Provenance[0]; {
+	case origin.Source == domain.MatchSourceNumeric:
+		// Numbers in text are far more often postal codes, house numbers or amounts
+		return nil
+	case origin.Source == domain.MatchSourceSubdivisionCode && !strings.Contains(key, "-"):
+		// Local subdivision codes (e.g. "NW") only count as part of the full code ("DE-NW")
+		return nil
+	case isCodeSource(origin.Source) || (origin.Source == domain.MatchSourceAlias && utf8.RuneCountInString(key) <= maxShortAliasLength):
+		if !isCapitals(span) {
+			return nil
+		}
+		if commonWords[key] {
+			if !rules.lowerCase {
+				return nil
+			}
+			match.Score = scoreCommonWord
+		}
+	case rules.upperCase && startsLower(span):
+		match.Score = roundScore(match.Score * scoreLowerCaseFactor)
+	}
+
+	return match
+}
+
+// maxKeyWords returns the most words in any key of the index
+func (idx *countryIndex) maxKeyWords() int {
+	longest := 0
+	count := func(key string) {
+		longest = max(longest, len(strings.Fields(key)))
+	}
+	for key := range idx.nameToCode {
+		count(key)
+	}
+	for key := range idx.ambiguous {
+		count(key)
+	}
+	for key := range idx.historical {
+		count(key)
+	}
+	for key := range idx.subdivisions {
+		count(key)
+	}
+	return longest
+}
+
+// tokenize splits a text into words, see token
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range text {
+		if isWordRune(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start < 0 {
+			continue
+		}
+		if isJoiner(r) {
+			_, size := utf8.DecodeRuneInString(text[i:])
+			if next, _ := utf8.DecodeRuneInString(text[i+size:]); isWordRune(next) {
+				continue
+			}
+		}
+		tokens = append(tokens, token{start: start, end: i})
+		start = -1
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{start: start, end: len(text)})
+	}
+	return tokens
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+}
+
+// isJoiner reports whether r can join two parts of a word
+func isJoiner(r rune) bool {
+	switch r {
+	case '\'', '’', '-', '.':
+		return true
+	}
+	return false
+}
+
+// isCodeSource reports whether keys from source are codes rather than names or aliases
+func isCodeSource(source domain.MatchSource) bool {
+	switch source {
+	case domain.MatchSourceISO2, domain.MatchSourceISO3, domain.MatchSourceHistoricalCode, domain.MatchSourceSubdivisionCode:
+		return true
+	}
+	return false
+}
+
+// isCapitals reports whether s has upper-case letters and no lower-case ones
+func isCapitals(s string) bool {
+	return strings.ToUpper(s) == s && strings.ToLower(s) != s
+}
+
+// startsLower reports whether s starts with a lower-case letter
+func startsLower(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return unicode.IsLower(r)
+}
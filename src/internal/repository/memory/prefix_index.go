@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"sort"
+	"strings"
+)
+
+// prefixEntry is one normalized key of the prefix index
+type prefixEntry struct {
+	key   string
+	entry indexEntry
+}
+
+// prefixIndex keeps every normalized name, alias and code in sorted order,
+// so all keys starting with a prefix form one contiguous range found by binary search
+type prefixIndex struct {
+	entries []prefixEntry
+}
+
+// newPrefixIndex builds the sorted index from the repository's name maps
+func newPrefixIndex(nameToCode map[string]indexEntry, ambiguous map[string][]indexEntry) *prefixIndex {
+	entries := make([]prefixEntry, 0, len(nameToCode)+len(ambiguous))
+	for key, entry := range nameToCode {
+		entries = append(entries, prefixEntry{key: key, entry: entry})
+	}
+	for key, ambiguousEntries := range ambiguous {
+		for _, entry := range ambiguousEntries {
+			entries = append(entries, prefixEntry{key: key, entry: entry})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].key != entries[j].key {
+			return entries[i].key < entries[j].key
+		}
+		return entries[i].entry.code < entries[j].entry.code
+	})
+
+	return &prefixIndex{entries: entries}
+}
+
+// search returns all entries whose key starts with prefix, in key order
+func (p *prefixIndex) search(prefix string) []prefixEntry {
+	start := sort.Search(len(p.entries), func(i int) bool {
+		return p.entries[i].key >= prefix
+	})
+
+	end := start
+	for end < len(p.entries) && strings.HasPrefix(p.entries[end].key, prefix) {
+		end++
+	}
+
+	return p.entries[start:end]
+}
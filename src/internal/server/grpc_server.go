@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"country-iso-matcher/src/internal/config"
+	"country-iso-matcher/src/internal/handler"
+	"country-iso-matcher/src/internal/handler/middleware"
+	"country-iso-matcher/src/internal/service"
+	pb "country-iso-matcher/src/pkg/pb/countrymatcher/v1"
+)
+
+type grpcServer struct {
+	server *grpc.Server
+	health *health.Server
+	addr   string
+	logger *slog.Logger
+}
+
+func NewGRPCServer(cfg *config.Config, countryService service.CountryService, logger *slog.Logger) Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(middleware.GRPCUnaryInterceptor(logger)),
+		grpc.ChainStreamInterceptor(middleware.GRPCStreamInterceptor(logger)),
+	)
+
+	pb.RegisterCountryMatcherServer(server, handler.NewCountryGRPCHandler(countryService, logger))
+
+	// Standard health service; "" reports the server as a whole
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus(pb.CountryMatcher_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	if cfg.GRPC.Reflection {
+		reflection.Register(server)
+	}
+
+	return &grpcServer{
+		server: server,
+		health: healthServer,
+		addr:   cfg.Server.Host + ":" + cfg.GRPC.Port,
+		logger: logger,
+	}
+}
+
+func (s *grpcServer) Start() error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("grpc server failed to listen: %w", err)
+	}
+
+	s.logger.Info("starting gRPC server", "addr", s.addr)
+	if err := s.server.Serve(listener); err != nil && err != grpc.ErrServerStopped {
+		return fmt.Errorf("grpc server failed to start: %w", err)
+	}
+	return nil
+}
+
+// Shutdown waits for in-flight calls to finish, or stops immediately once ctx is done
+func (s *grpcServer) Shutdown(ctx context.Context) error {
+	s.logger.Info("shutting down gRPC server")
+	s.health.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
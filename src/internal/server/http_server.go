@@ -25,15 +25,36 @@ type httpServer struct {
 	logger  *slog.Logger
 }
 
-func NewHTTPServer(cfg *config.Config, countryHandler handler.CountryHandler, countryService service.CountryService, logger *slog.Logger) Server {
+func NewHTTPServer(cfg *config.Config, countryHandler handler.CountryHandler, adminHandler handler.AdminHandler, countryService service.CountryService, logger *slog.Logger) Server {
 	mux := http.NewServeMux()
+	stream := middleware.StreamTimeout(time.Duration(cfg.Server.StreamTimeout)*time.Second, logger)
 
 	// API Routes
 	mux.HandleFunc("/api/convert", countryHandler.ConvertCountry)
+	mux.HandleFunc("/api/v2/convert", countryHandler.ConvertCountryV2)
+	mux.HandleFunc("/api/v1/convert/batch", countryHandler.ConvertBatch)
+	mux.Handle("/api/v1/convert/stream", stream(http.HandlerFunc(countryHandler.ConvertStream)))
+	mux.Handle("/api/v1/enrich/csv", stream(http.HandlerFunc(countryHandler.EnrichCSV)))
+	mux.HandleFunc("/api/v1/address", countryHandler.ResolveAddress)
+	mux.HandleFunc("/api/v1/locale", countryHandler.ResolveLocale)
+	mux.HandleFunc("/api/v1/extract", countryHandler.ExtractCountries)
+	mux.HandleFunc("/api/v1/suggest", countryHandler.SuggestCountries)
+	mux.HandleFunc("/api/v1/autocomplete", countryHandler.AutocompleteCountries)
+	mux.HandleFunc("/api/v1/codes/convert", countryHandler.ConvertCode)
+	mux.HandleFunc("/api/v1/countries", countryHandler.ListCountries)
+	mux.HandleFunc("/api/v1/countries/{code}", countryHandler.GetCountry)
+	mux.HandleFunc("/api/v1/subdivisions", countryHandler.ListSubdivisions)
+	mux.HandleFunc("/api/v1/subdivisions/resolve", countryHandler.ResolveSubdivision)
 	mux.HandleFunc("/health", countryHandler.Health)
 	mux.HandleFunc("/stats", countryHandler.GetStats)
 	mux.Handle("/metrics", promhttp.Handler()) // Prometheus metrics endpoint
 
+	// Admin Routes (if enabled), only for clients sending the admin token
+	if cfg.Admin.Enabled {
+		admin := middleware.BearerToken(cfg.Admin.Token)
+		mux.Handle("/api/v1/admin/reload", admin(http.HandlerFunc(adminHandler.ReloadData)))
+	}
+
 	// GUI Routes (if enabled)
 	if cfg.GUI.Enabled {
 		guiHandler := gui.NewHandler(logger)
@@ -0,0 +1,358 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"country-iso-matcher/src/internal/domain"
+	"country-iso-matcher/src/pkg/locale"
+)
+
+const (
+	// addressTailLines is how many lines at the end of an address are searched for the country
+	addressTailLines = 3
+	// addressLineDecay lowers the score of a mention for every line it is above the last line
+	addressLineDecay = 0.2
+	// postalCodeBonus is added to the score of candidates with a postal code in one of their formats
+	postalCodeBonus = 0.3
+	// postalMismatchFactor scales the score of named candidates whose formats reject a postal
+	// code that fits the formats of other countries
+	postalMismatchFactor = 0.4
+	// postalOnlyConfidence is the confidence of a country found by its postal code format alone
+	postalOnlyConfidence = 0.5
+	// postalSharedConfidence is the confidence of a country found by a postal code format
+	// that other countries share
+	postalSharedConfidence = 0.2
+	// subdivisionBonus is added to the score of a postal code candidate when the address
+	// names one of its subdivisions (e.g. "CA" for US, "Berlin" for DE)
+	subdivisionBonus = 0.3
+)
+
+// addressLine is a line of an address, or a comma-separated part of a one-line address
+type addressLine struct {
+	number int // Counting from 1
+	text   string
+}
+
+// postalCode is a part of an address that may be a postal code
+type postalCode struct {
+	code string
+	line int
+}
+
+// addressCandidate is a country under consideration while resolving an address
+type addressCandidate struct {
+	country  *domain.Country
+	score    float64
+	distance int // Lines between the mention and the last line
+	result   domain.AddressCandidate
+}
+
+// ResolveAddress finds the country of a postal address. Countries named in the last lines
+// of the address are candidates, weighted by how close to the end they appear; a postal
+// code in one of a candidate's formats (e.g. \d{6} for RO) raises its score, and one that
+// only fits the formats of other countries lowers it. The countries whose formats fit the
+// postal code closest to the end are candidates too, with a low confidence unless the
+// format is theirs alone, raised when the address names one of their subdivisions.
+// Every candidate explains its score.
+func (s *countryService) ResolveAddress(address string, opts LookupOptions) (*domain.AddressResponse, error) {
+	lines := addressLines(address)
+	if len(lines) == 0 {
+		return nil, domain.NewValidationError("Address is required", "")
+	}
+	tail := lines[max(0, len(lines)-addressTailLines):]
+	codes := postalCodes(tail)
+
+	var candidates []*addressCandidate
+	byCountry := make(map[string]*addressCandidate)
+	for i := len(tail) - 1; i >= 0; i-- {
+		line := tail[i]
+		distance := len(tail) - 1 - i
+		weight := 1 - addressLineDecay*float64(distance)
+
+		for _, extraction := range s.repository.Extract(line.text) {
+			match := extraction.Match
+			score := match.Score * weight
+			existing, exists := byCountry[match.Country.ISO2]
+			if exists && existing.score >= score {
+				continue
+			}
+
+			candidate := &addressCandidate{country: match.Country, score: score, distance: distance}
+			candidate.result = domain.AddressCandidate{
+				Country: domain.NewCountryInfo(match.Country),
+				Mention: extraction.Text,
+				Line:    line.number,
+				Explanation: []string{fmt.Sprintf("%q on line %d is %s of %s",
+					extraction.Text, line.number, describeMatch(match.Type), match.Country.GetOfficialName())},
+			}
+			if distance > 0 {
+				candidate.result.Explanation = append(candidate.result.Explanation, fmt.Sprintf(
+					"line %d is %d line(s) above the last line, so the mention counts %.0f%%", line.number, distance, weight*100))
+			}
+			if exists {
+				*existing = *candidate
+				continue
+			}
+			byCountry[match.Country.ISO2] = candidate
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	// Postal codes come after the street, so a number above every mention is not one
+	named, from := len(candidates) > 0, 0
+	for _, candidate := range candidates {
+		if from == 0 || candidate.result.Line < from {
+			from = candidate.result.Line
+		}
+	}
+	fitting, countries := s.fittingPostalCode(codes, from)
+	for _, candidate := range candidates {
+		s.checkPostalCode(candidate, codes, fitting)
+	}
+	for _, country := range countries {
+		if _, exists := byCountry[country.ISO2]; exists {
+			continue
+		}
+		candidate := s.postalCandidate(country, fitting, len(countries))
+		if !named {
+			candidate.result.Explanation = append([]string{
+				fmt.Sprintf("no country is named in the last %d line(s)", len(tail))}, candidate.result.Explanation...)
+		}
+		s.checkSubdivision(candidate, tail)
+		byCountry[country.ISO2] = candidate
+		candidates = append(candidates, candidate)
+	}
+
+	if len(candidates) == 0 {
+		return nil, &domain.AppError{
+			Code:    404,
+			Message: "No country is named in the address and no postal code in it fits a known format",
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].country.ISO2 < candidates[j].country.ISO2
+	})
+
+	var chain []string
+	if len(opts.Languages) > 0 {
+		chain = locale.FallbackChain(opts.Languages, DefaultLanguage)
+	}
+
+	response := &domain.AddressResponse{Candidates: make([]domain.AddressCandidate, 0, len(candidates))}
+	for _, candidate := range candidates {
+		candidate.result.Confidence = math.Round(min(candidate.score, 1)*1000) / 1000
+		if chain != nil {
+			candidate.result.Country.Localize(candidate.country, chain)
+		}
+		response.Candidates = append(response.Candidates, candidate.result)
+	}
+	response.AddressCandidate = response.Candidates[0]
+
+	return response, nil
+}
+
+// checkPostalCode raises the score of a candidate when a postal code of the address, the
+// closest to the end first, is in one of the candidate's formats. When none is, but fitting
+// is in the formats of other countries, the score is lowered. Either outcome is explained.
+func (s *countryService) checkPostalCode(candidate *addressCandidate, codes []postalCode, fitting *postalCode) {
+	name := candidate.country.GetOfficialName()
+	formats := s.repository.PostalCodeFormats(candidate.country.ISO2)
+	if len(formats) == 0 {
+		candidate.result.Explanation = append(candidate.result.Explanation,
+			fmt.Sprintf("no postal code formats are known for %s", name))
+		return
+	}
+
+	for _, code := range codes {
+		for _, format := range formats {
+			if format.Matches(code.code) {
+				candidate.score += postalCodeBonus
+				candidate.result.PostalCode = code.code
+				candidate.result.PostalPattern = format.Pattern
+				candidate.result.Explanation = append(candidate.result.Explanation,
+					fmt.Sprintf("%q on line %d matches the postal code format %s of %s", code.code, code.line, format.Pattern, name))
+				return
+			}
+		}
+	}
+
+	if fitting != nil {
+		candidate.score *= postalMismatchFactor
+		candidate.result.Explanation = append(candidate.result.Explanation, fmt.Sprintf(
+			"%q on line %d fits the postal code formats of other countries but not those of %s, so the mention counts %.0f%%",
+			fitting.code, fitting.line, name, postalMismatchFactor*100))
+		return
+	}
+
+	candidate.result.Explanation = append(candidate.result.Explanation,
+		fmt.Sprintf("no postal code in the address matches the formats of %s", name))
+}
+
+// fittingPostalCode returns the postal code of the address closest to the end, and not
+// above line from, that fits any country's format, with the countries whose formats it fits
+func (s *countryService) fittingPostalCode(codes []postalCode, from int) (*postalCode, []*domain.Country) {
+	for i := range codes {
+		if codes[i].line < from {
+			break // Closest to the end first, so the rest is above from as well
+		}
+		if countries := s.repository.PostalCodeCountries(codes[i].code); len(countries) > 0 {
+			return &codes[i], countries
+		}
+	}
+	return nil, nil
+}
+
+// postalCandidate makes a candidate of a country whose format fits the postal code, which
+// fits the formats of shared countries in all. Its confidence is low when shared is above 1.
+func (s *countryService) postalCandidate(country *domain.Country, code *postalCode, shared int) *addressCandidate {
+	var pattern string
+	for _, format := range s.repository.PostalCodeFormats(country.ISO2) {
+		if format.Matches(code.code) {
+			pattern = format.Pattern
+			break
+		}
+	}
+
+	candidate := &addressCandidate{
+		country: country,
+		score:   postalOnlyConfidence,
+		result: domain.AddressCandidate{
+			Country:       domain.NewCountryInfo(country),
+			PostalCode:    code.code,
+			PostalPattern: pattern,
+		},
+	}
+	if shared == 1 {
+		candidate.result.Explanation = []string{fmt.Sprintf("%q on line %d matches only the postal code format %s of %s",
+			code.code, code.line, pattern, country.GetOfficialName())}
+		return candidate
+	}
+
+	candidate.score = postalSharedConfidence
+	candidate.result.Explanation = []string{fmt.Sprintf("%q on line %d matches the postal code format %s of %s, one of %d countries with a fitting format",
+		code.code, code.line, pattern, country.GetOfficialName(), shared)}
+	return candidate
+}
+
+// checkSubdivision raises the score of a postal code candidate when a part of the address,
+// the closest to the end first, names one of the candidate's subdivisions
+func (s *countryService) checkSubdivision(candidate *addressCandidate, lines []addressLine) {
+	for _, term := range addressTerms(lines) {
+		match, err := s.repository.MatchSubdivision(term.text, candidate.country.ISO2)
+		if err != nil || match.Subdivision == nil {
+			continue
+		}
+		candidate.score += subdivisionBonus
+		candidate.result.Explanation = append(candidate.result.Explanation, fmt.Sprintf("%q on line %d is the %s %s (%s) of %s",
+			term.text, term.number, match.Subdivision.Type, match.Subdivision.GetName(), match.Subdivision.Code, candidate.country.GetOfficialName()))
+		return
+	}
+}
+
+// addressLines splits an address into its non-empty lines. A one-line address is split
+// at its commas instead, so "10 Main St, Springfield, USA" has three lines.
+func addressLines(address string) []addressLine {
+	parts := strings.Split(strings.ReplaceAll(address, "\r\n", "\n"), "\n")
+	var nonEmpty []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	if len(nonEmpty) == 1 {
+		line := nonEmpty[0]
+		nonEmpty = nil
+		for _, part := range strings.Split(line, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				nonEmpty = append(nonEmpty, part)
+			}
+		}
+	}
+
+	lines := make([]addressLine, len(nonEmpty))
+	for i, text := range nonEmpty {
+		lines[i] = addressLine{number: i + 1, text: text}
+	}
+	return lines
+}
+
+// postalCodes returns the parts of the lines that may be postal codes, the closest to the
+// end of the address first: words with a digit, the same without a country prefix such as
+// "D-" or "RO-", and pairs of words with a digit (e.g. "SW1A 1AA", "1012 JS")
+func postalCodes(lines []addressLine) []postalCode {
+	var codes []postalCode
+	for i := len(lines) - 1; i >= 0; i-- {
+		words := strings.FieldsFunc(lines[i].text, func(r rune) bool {
+			return unicode.IsSpace(r) || r == ','
+		})
+		for j := range words {
+			words[j] = strings.Trim(words[j], ".;:()[]")
+		}
+
+		for j, word := range words {
+			if j+1 < len(words) && (hasDigit(word) || hasDigit(words[j+1])) {
+				codes = append(codes, postalCode{code: word + " " + words[j+1], line: lines[i].number})
+			}
+			if !hasDigit(word) {
+				continue
+			}
+			codes = append(codes, postalCode{code: word, line: lines[i].number})
+			if prefix, rest, found := strings.Cut(word, "-"); found && len(prefix) <= 3 && !hasDigit(prefix) {
+				codes = append(codes, postalCode{code: rest, line: lines[i].number})
+			}
+		}
+	}
+	return codes
+}
+
+// addressTerms returns the parts of the lines that may name a subdivision, the closest to
+// the end of the address first: the comma-separated parts and the words without digits
+func addressTerms(lines []addressLine) []addressLine {
+	var terms []addressLine
+	for i := len(lines) - 1; i >= 0; i-- {
+		for _, part := range strings.Split(lines[i].text, ",") {
+			if part = strings.TrimSpace(part); part != "" && !hasDigit(part) {
+				terms = append(terms, addressLine{number: lines[i].number, text: part})
+			}
+		}
+		for _, word := range strings.FieldsFunc(lines[i].text, func(r rune) bool {
+			return unicode.IsSpace(r) || r == ','
+		}) {
+			if word = strings.Trim(word, ".;:()[]"); word != "" && !hasDigit(word) {
+				terms = append(terms, addressLine{number: lines[i].number, text: word})
+			}
+		}
+	}
+	return terms
+}
+
+func hasDigit(s string) bool {
+	return strings.ContainsFunc(s, unicode.IsDigit)
+}
+
+// describeMatch describes how a mention relates to its country in explanations
+func describeMatch(matchType domain.MatchType) string {
+	switch matchType {
+	case domain.MatchTypeAlias:
+		return "an alias"
+	case domain.MatchTypeCode:
+		return "a code"
+	case domain.MatchTypeSubdivision:
+		return "a subdivision"
+	case domain.MatchTypeHistorical:
+		return "a former name or code"
+	default:
+		return "a name"
+	}
+}
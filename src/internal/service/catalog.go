@@ -0,0 +1,187 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+
+	"country-iso-matcher/src/internal/domain"
+	"country-iso-matcher/src/pkg/locale"
+)
+
+const (
+	// DefaultCatalogLimit is the page size of the country catalog when no limit is given
+	DefaultCatalogLimit = 50
+	// MaxCatalogLimit caps the page size of the country catalog; one page holds all of ISO 3166-1
+	MaxCatalogLimit = 300
+)
+
+// Sort keys of the country catalog
+const (
+	CatalogSortISO2    = "iso2"
+	CatalogSortISO3    = "iso3"
+	CatalogSortNumeric = "numeric"
+	CatalogSortName    = "name"
+)
+
+// CatalogQuery selects and orders a page of the country catalog
+type CatalogQuery struct {
+	// Region keeps countries whose continent, UN region or subregion equals it, ignoring case
+	Region string
+	// Language keeps countries with a name in this BCP 47 language or one of its parents
+	Language string
+	// Sort is one of the CatalogSort keys; name sorts by display name. Empty means iso2.
+	Sort       string
+	Descending bool
+	// Offset skips that many matching countries; Limit 0 means DefaultCatalogLimit
+	Offset int
+	Limit  int
+}
+
+// ListCountries returns a page of the countries the matcher knows, filtered and sorted as
+// requested. Display names follow opts.Languages, and opts.IncludeMeta adds metadata.
+func (s *countryService) ListCountries(query CatalogQuery, opts LookupOptions) (*domain.CatalogResponse, error) {
+	if query.Limit == 0 {
+		query.Limit = DefaultCatalogLimit
+	}
+	if query.Limit < 0 || query.Limit > MaxCatalogLimit {
+		return nil, domain.NewValidationError(fmt.Sprintf("limit must be between 1 and %d", MaxCatalogLimit), "")
+	}
+	if query.Offset < 0 {
+		return nil, domain.NewValidationError("offset must not be negative", "")
+	}
+	if query.Sort == "" {
+		query.Sort = CatalogSortISO2
+	}
+
+	var coverage []string
+	if query.Language != "" {
+		tag, err := language.Parse(query.Language)
+		if err != nil {
+			return nil, domain.NewValidationError(fmt.Sprintf("invalid language tag %q", query.Language), "")
+		}
+		coverage = locale.FallbackChain([]string{tag.String()}, "")
+	}
+
+	var chain []string
+	if len(opts.Languages) > 0 {
+		chain = locale.FallbackChain(opts.Languages, DefaultLanguage)
+	}
+
+	less, err := catalogOrder(query.Sort, chain)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []domain.CatalogEntry
+	for _, country := range s.repository.Countries() {
+		if query.Region != "" && !inRegion(country, query.Region) {
+			continue
+		}
+		if coverage != nil && !hasNameIn(country, coverage) {
+			continue
+		}
+
+		entry := domain.CatalogEntry{CountryInfo: domain.NewCountryInfo(country)}
+		if chain != nil {
+			entry.Localize(country, chain)
+		}
+		if opts.IncludeMeta {
+			entry.Meta = domain.NewMetaInfo(country)
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if query.Descending {
+			return less(entries[j], entries[i])
+		}
+		return less(entries[i], entries[j])
+	})
+
+	response := &domain.CatalogResponse{
+		Total:     len(entries),
+		Offset:    query.Offset,
+		Limit:     query.Limit,
+		Countries: []domain.CatalogEntry{},
+	}
+	if query.Offset < len(entries) {
+		end := min(query.Offset+query.Limit, len(entries))
+		response.Countries = entries[query.Offset:end]
+	}
+
+	return response, nil
+}
+
+// GetCountryRecord returns the full record of the country with an ISO 3166-1 alpha-2,
+// alpha-3 or numeric code: every name, alias and code, and its metadata
+func (s *countryService) GetCountryRecord(code string, opts LookupOptions) (*domain.CountryRecord, error) {
+	country, err := s.findCountry(code)
+	if err != nil {
+		return nil, err
+	}
+
+	record := domain.NewCountryRecord(country, s.repository.Aliases(country.ISO2))
+	if len(opts.Languages) > 0 {
+		record.Localize(country, locale.FallbackChain(opts.Languages, DefaultLanguage))
+	}
+
+	return record, nil
+}
+
+// catalogOrder returns the comparison for a sort key. Names sort by the collation of the
+// first display language, so accented names land where readers of that language expect them.
+func catalogOrder(key string, chain []string) (func(a, b domain.CatalogEntry) bool, error) {
+	switch key {
+	case CatalogSortISO2:
+		return func(a, b domain.CatalogEntry) bool { return a.ISO2Code < b.ISO2Code }, nil
+	case CatalogSortISO3:
+		return func(a, b domain.CatalogEntry) bool { return a.ISO3Code < b.ISO3Code }, nil
+	case CatalogSortNumeric:
+		return func(a, b domain.CatalogEntry) bool { return a.NumericCode < b.NumericCode }, nil
+	case CatalogSortName:
+		tag := language.English
+		if len(chain) > 0 {
+			tag = language.Make(chain[0])
+		}
+		collator := collate.New(tag)
+		name := func(entry domain.CatalogEntry) string {
+			if entry.LocalizedName != "" {
+				return entry.LocalizedName
+			}
+			return entry.OfficialName
+		}
+		return func(a, b domain.CatalogEntry) bool {
+			return collator.CompareString(name(a), name(b)) < 0
+		}, nil
+	default:
+		return nil, domain.NewValidationError(fmt.Sprintf("Unknown sort key %q (must be one of %s, %s, %s, %s)",
+			key, CatalogSortISO2, CatalogSortISO3, CatalogSortNumeric, CatalogSortName), "")
+	}
+}
+
+// inRegion reports whether the country's continent, UN region or subregion is region
+func inRegion(country *domain.Country, region string) bool {
+	if country.Meta == nil {
+		return false
+	}
+	for _, value := range []string{country.Meta.Continent, country.Meta.Region, country.Meta.Subregion} {
+		if value != "" && strings.EqualFold(value, strings.TrimSpace(region)) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasNameIn reports whether the country has a name for one of the language keys
+func hasNameIn(country *domain.Country, keys []string) bool {
+	for _, key := range keys {
+		if country.Names[key] != "" {
+			return true
+		}
+	}
+	return false
+}
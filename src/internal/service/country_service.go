@@ -1,12 +1,28 @@
 package service
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
 	"country-iso-matcher/src/internal/domain"
 	"country-iso-matcher/src/internal/metrics"
 	"country-iso-matcher/src/internal/repository"
+	"country-iso-matcher/src/pkg/locale"
+)
+
+const (
+	// DefaultSuggestLimit is the number of suggestions returned when no limit is given
+	DefaultSuggestLimit = 5
+	// MaxSuggestLimit caps the number of suggestions per request
+	MaxSuggestLimit = 50
+
+	// DefaultAutocompleteLimit is the number of autocomplete results returned when no limit is given
+	DefaultAutocompleteLimit = 10
+	// MaxAutocompleteLimit caps the number of autocomplete results per request
+	MaxAutocompleteLimit = 50
+	// DefaultLanguage is used for display names when no language is requested
+	DefaultLanguage = "en"
 )
 
 type countryService struct {
@@ -20,6 +36,132 @@ func NewCountryService(repo repository.CountryRepository) CountryService {
 }
 
 func (s *countryService) LookupCountry(query string) (*domain.CountryResponse, error) {
+	return s.Lookup(query, LookupOptions{})
+}
+
+func (s *countryService) Lookup(query string, opts LookupOptions) (*domain.CountryResponse, error) {
+	query = strings.TrimSpace(query)
+	match, err := s.match(query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	response := domain.NewMatchResponse(query, match)
+	applyLookupOptions(response, match, opts)
+	return response, nil
+}
+
+func (s *countryService) LookupDetailed(query string, opts LookupOptions) (*domain.CountryResponseV2, error) {
+	query = strings.TrimSpace(query)
+	match, err := s.match(query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	response := domain.NewCountryResponseV2(query, match)
+	applyLookupOptions(response, match, opts)
+	return response, nil
+}
+
+// lookupResponse is a lookup response of either API version
+type lookupResponse interface {
+	LocalizeMatch(match *domain.Match, chain []string)
+	AddMeta(country *domain.Country)
+}
+
+// applyLookupOptions localizes a lookup response to opts.Languages and adds the metadata
+// block when opts.IncludeMeta is set
+func applyLookupOptions(response lookupResponse, match *domain.Match, opts LookupOptions) {
+	if len(opts.Languages) > 0 {
+		response.LocalizeMatch(match, locale.FallbackChain(opts.Languages, DefaultLanguage))
+	}
+	if opts.IncludeMeta {
+		response.AddMeta(match.Country)
+	}
+}
+
+// LookupBatch resolves every query independently and returns the results in request order.
+// A failing query does not fail the batch; its error is reported on the item instead.
+func (s *countryService) LookupBatch(queries []string, opts LookupOptions) *domain.BatchResponse {
+	items := make([]domain.BatchItem, len(queries))
+	for i, query := range queries {
+		items[i] = s.LookupItem(i, query, opts)
+	}
+
+	return domain.NewBatchResponse(items)
+}
+
+// LookupItem resolves one query of a batch or stream. Failures are reported in the
+// item's status and error instead of being returned, so callers can keep going.
+// Locales naming a UN M49 area are reported with the region status and the area.
+func (s *countryService) LookupItem(index int, query string, opts LookupOptions) domain.BatchItem {
+	item := domain.BatchItem{Index: index, Query: query}
+
+	if opts.Kind == KindLocale {
+		if id, err := locale.ParseIdentifier(query); err == nil && id.MacroRegion {
+			item.Status = domain.BatchStatusRegion
+			item.Region = &domain.RegionInfo{Code: id.Region, Name: id.RegionName}
+			return item
+		}
+	}
+
+	result, err := s.Lookup(query, opts)
+	if err != nil {
+		appErr, ok := err.(*domain.AppError)
+		if !ok {
+			appErr = domain.NewInternalError("Internal server error")
+		}
+		item.Status = domain.ErrorStatus(appErr)
+		item.Error = appErr
+		return item
+	}
+
+	item.Status = domain.BatchStatusOK
+	item.Result = result
+	return item
+}
+
+// GetCountry returns the country with an ISO 3166-1 alpha-2, alpha-3 or numeric code.
+// Codes of withdrawn countries (ISO 3166-3) yield a historical match.
+func (s *countryService) GetCountry(code string, opts LookupOptions) (*domain.CountryResponse, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if numeric, ok := domain.NumericCode(code); ok {
+		code = numeric
+	} else if len(code) != 2 && len(code) != 3 && !domain.IsHistoricalCode(code) {
+		return nil, domain.NewValidationError("Country code must have 2 or 3 letters, be a numeric code or an ISO 3166-3 code", code)
+	}
+
+	country, err := s.repository.FindByCode(code)
+	if appErr, ok := err.(*domain.AppError); ok && appErr.Code == 404 {
+		// Withdrawn codes are reported as historical matches instead of not found
+		return s.getHistorical(code, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := domain.NewCountryResponse(code, country)
+	response.MatchType = string(domain.MatchTypeCode)
+	applyLookupOptions(response, &domain.Match{Country: country}, opts)
+	return response, nil
+}
+
+// getHistorical returns the withdrawn country with an ISO 3166-3 code or former ISO 3166-1 code
+func (s *countryService) getHistorical(code string, opts LookupOptions) (*domain.CountryResponse, error) {
+	match, err := s.repository.FindHistorical(code)
+	if err != nil {
+		return nil, err
+	}
+
+	response := domain.NewMatchResponse(code, match)
+	applyLookupOptions(response, match, opts)
+	return response, nil
+}
+
+// match resolves a trimmed query through the repository and records lookup metrics.
+// Nationality queries try the demonyms first and fall back to the names when no demonym matches;
+// locale queries resolve through their region subtag.
+func (s *countryService) match(query string, opts LookupOptions) (*domain.Match, error) {
 	start := time.Now()
 	var result string
 
@@ -28,21 +170,36 @@ func (s *countryService) LookupCountry(query string) (*domain.CountryResponse, e
 		metrics.CountryLookupDuration.WithLabelValues(result).Observe(duration)
 	}()
 
-	query = strings.TrimSpace(query)
 	if query == "" {
 		result = "validation_error"
 		metrics.CountryLookupsTotal.WithLabelValues("validation_error").Inc()
 		return nil, domain.NewValidationError("Country query parameter is required", query)
 	}
 
-	country, err := s.repository.FindByName(query)
+	var match *domain.Match
+	var err error
+	switch opts.Kind {
+	case KindNationality:
+		match, err = s.repository.MatchDemonym(query)
+		if appErr, ok := err.(*domain.AppError); ok && appErr.Code == 404 {
+			match, err = s.repository.MatchByName(query)
+		}
+	case KindLocale:
+		match, err = s.matchLocale(query, opts.Likely)
+	default:
+		match, err = s.repository.MatchByName(query)
+	}
 	if err != nil {
 		// Check if it's a not found error or other error
 		if appErr, ok := err.(*domain.AppError); ok {
-			if appErr.Code == 404 {
+			switch appErr.Code {
+			case 404:
 				result = "not_found"
 				metrics.CountryLookupsTotal.WithLabelValues("not_found").Inc()
-			} else {
+			case 409:
+				result = "ambiguous"
+				metrics.CountryLookupsTotal.WithLabelValues("ambiguous").Inc()
+			default:
 				result = "error"
 				metrics.CountryLookupsTotal.WithLabelValues("error").Inc()
 			}
@@ -57,7 +214,200 @@ func (s *countryService) LookupCountry(query string) (*domain.CountryResponse, e
 	metrics.CountryLookupsTotal.WithLabelValues("success").Inc()
 
 	// Track popular countries for successful lookups
+	country := match.Country
 	metrics.PopularCountries.WithLabelValues(country.ISO2, country.GetOfficialName()).Inc()
 
-	return domain.NewCountryResponse(query, country), nil
+	return match, nil
+}
+
+func (s *countryService) SuggestCountries(query string, limit int) (*domain.SuggestResponse, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, domain.NewValidationError("Query parameter q is required", query)
+	}
+
+	if limit == 0 {
+		limit = DefaultSuggestLimit
+	}
+	if limit < 0 || limit > MaxSuggestLimit {
+		return nil, domain.NewValidationError(fmt.Sprintf("limit must be between 1 and %d", MaxSuggestLimit), query)
+	}
+
+	matches := s.repository.Suggest(query, limit)
+	suggestions := make([]domain.Suggestion, 0, len(matches))
+	for _, match := range matches {
+		suggestions = append(suggestions, domain.NewSuggestion(match))
+	}
+
+	return &domain.SuggestResponse{
+		Query:       query,
+		Suggestions: suggestions,
+	}, nil
+}
+
+func (s *countryService) AutocompleteCountries(prefix string, languages []string, limit int) (*domain.AutocompleteResponse, error) {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return nil, domain.NewValidationError("Query parameter prefix is required", prefix)
+	}
+
+	if limit == 0 {
+		limit = DefaultAutocompleteLimit
+	}
+	if limit < 0 || limit > MaxAutocompleteLimit {
+		return nil, domain.NewValidationError(fmt.Sprintf("limit must be between 1 and %d", MaxAutocompleteLimit), prefix)
+	}
+
+	lang := DefaultLanguage
+	if len(languages) > 0 {
+		lang = languages[0]
+	}
+	chain := locale.FallbackChain(languages, DefaultLanguage)
+
+	matches := s.repository.Autocomplete(prefix, limit)
+	results := make([]domain.AutocompleteItem, 0, len(matches))
+	for _, match := range matches {
+		results = append(results, domain.NewAutocompleteItem(match, chain))
+	}
+
+	return &domain.AutocompleteResponse{
+		Prefix:   prefix,
+		Language: lang,
+		Results:  results,
+	}, nil
+}
+
+// ConvertCode converts a country code from one code system to another (see domain.CodeSystems).
+// A code shared by several countries in the source system is ambiguous, and a country
+// without a code in the target system is reported as not found.
+func (s *countryService) ConvertCode(from, to, code string, opts LookupOptions) (*domain.CodeConversionResponse, error) {
+	from = strings.ToLower(strings.TrimSpace(from))
+	to = strings.ToLower(strings.TrimSpace(to))
+	systems := strings.Join(domain.CodeSystems, ", ")
+
+	if !domain.IsCodeSystem(from) {
+		return nil, domain.NewValidationError(fmt.Sprintf("Unknown code system in from: %q (must be one of %s)", from, systems), code)
+	}
+	if !domain.IsCodeSystem(to) {
+		return nil, domain.NewValidationError(fmt.Sprintf("Unknown code system in to: %q (must be one of %s)", to, systems), code)
+	}
+	if strings.TrimSpace(code) == "" {
+		return nil, domain.NewValidationError("Query parameter code is required", code)
+	}
+
+	normalized, ok := domain.NormalizeCode(from, code)
+	if !ok {
+		return nil, domain.NewValidationError(fmt.Sprintf("Invalid %s code: %s", from, code), code)
+	}
+
+	countries := s.repository.FindByCodeIn(from, normalized)
+	switch len(countries) {
+	case 0:
+		return nil, domain.NewCodeNotFoundError(from, normalized)
+	case 1:
+	default:
+		return nil, domain.NewAmbiguousCodeError(from, normalized, countries)
+	}
+
+	country := countries[0]
+	result := country.CodeIn(to)
+	if result == "" {
+		return nil, domain.NewMissingCodeError(to, country)
+	}
+
+	response := &domain.CodeConversionResponse{
+		From:    from,
+		To:      to,
+		Code:    normalized,
+		Result:  result,
+		Country: domain.NewCountryInfo(country),
+	}
+	if len(opts.Languages) > 0 {
+		response.Country.Localize(country, locale.FallbackChain(opts.Languages, DefaultLanguage))
+	}
+
+	return response, nil
+}
+
+// LookupSubdivision resolves the name or code of a subdivision (ISO 3166-2) to the subdivision
+// and its country. A country given as ISO 3166-1 code narrows the search to its subdivisions.
+func (s *countryService) LookupSubdivision(query, country string, opts LookupOptions) (*domain.SubdivisionResponse, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, domain.NewValidationError("Query parameter q is required", query)
+	}
+
+	var iso2 string
+	if strings.TrimSpace(country) != "" {
+		parent, err := s.findCountry(country)
+		if err != nil {
+			return nil, err
+		}
+		iso2 = parent.ISO2
+	}
+
+	match, err := s.repository.MatchSubdivision(query, iso2)
+	if err != nil {
+		return nil, err
+	}
+
+	var chain []string
+	if len(opts.Languages) > 0 {
+		chain = locale.FallbackChain(opts.Languages, DefaultLanguage)
+	}
+
+	response := &domain.SubdivisionResponse{
+		Query:       query,
+		Subdivision: domain.NewSubdivisionInfo(match.Subdivision, chain),
+		Country:     domain.NewCountryInfo(match.Country),
+		MatchType:   match.Type,
+	}
+	if chain != nil {
+		response.Country.Localize(match.Country, chain)
+	}
+
+	return response, nil
+}
+
+// ListSubdivisions returns the subdivisions of the country with an ISO 3166-1 code, sorted by code
+func (s *countryService) ListSubdivisions(country string, opts LookupOptions) (*domain.SubdivisionListResponse, error) {
+	if strings.TrimSpace(country) == "" {
+		return nil, domain.NewValidationError("Query parameter country is required", country)
+	}
+
+	parent, err := s.findCountry(country)
+	if err != nil {
+		return nil, err
+	}
+
+	var chain []string
+	if len(opts.Languages) > 0 {
+		chain = locale.FallbackChain(opts.Languages, DefaultLanguage)
+	}
+
+	subdivisions := s.repository.Subdivisions(parent.ISO2)
+	response := &domain.SubdivisionListResponse{
+		Country:      domain.NewCountryInfo(parent),
+		Subdivisions: make([]domain.SubdivisionInfo, 0, len(subdivisions)),
+	}
+	for _, subdivision := range subdivisions {
+		response.Subdivisions = append(response.Subdivisions, domain.NewSubdivisionInfo(subdivision, chain))
+	}
+	if chain != nil {
+		response.Country.Localize(parent, chain)
+	}
+
+	return response, nil
+}
+
+// findCountry resolves the ISO 3166-1 alpha-2, alpha-3 or numeric code of a current country
+func (s *countryService) findCountry(code string) (*domain.Country, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if numeric, ok := domain.NumericCode(code); ok {
+		code = numeric
+	} else if len(code) != 2 && len(code) != 3 {
+		return nil, domain.NewValidationError("Country code must have 2 or 3 letters or be a numeric code", code)
+	}
+
+	return s.repository.FindByCode(code)
 }
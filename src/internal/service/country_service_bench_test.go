@@ -0,0 +1,41 @@
+package service_test
+
+import (
+	"testing"
+
+	"country-iso-matcher/src/internal/config"
+	"country-iso-matcher/src/internal/data"
+	"country-iso-matcher/src/internal/repository/memory"
+	"country-iso-matcher/src/internal/service"
+	"country-iso-matcher/src/pkg/normalizer"
+)
+
+func BenchmarkCountryLookup(b *testing.B) {
+	// Setup
+	matching := config.DefaultConfig().Matching
+	repo, err := memory.NewCountryRepository(normalizer.NewTextNormalizer(), data.NewMemoryLoader(), &matching)
+	if err != nil {
+		b.Fatalf("failed to create repository: %v", err)
+	}
+	countryService := service.NewCountryService(repo)
+
+	countries := []string{
+		"Romania",
+		"Germany",
+		"United States",
+		"France",
+		"italy",
+		"SPAIN",
+		"united kingdom",
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		country := countries[i%len(countries)]
+		_, err := countryService.LookupCountry(country)
+		if err != nil {
+			b.Errorf("unexpected error: %v", err)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package service_test
 
 import (
+	"sort"
+	"strings"
 	"testing"
 
 	"country-iso-matcher/src/internal/domain"
@@ -8,7 +10,10 @@ import (
 )
 
 type mockRepository struct {
-	countries map[string]*domain.Country
+	countries    map[string]*domain.Country
+	historical   []domain.HistoricalCountry
+	subdivisions []domain.Subdivision
+	postalCodes  []domain.PostalCodeFormat
 }
 
 func (m *mockRepository) FindByName(name string) (*domain.Country, error) {
@@ -21,19 +26,164 @@ func (m *mockRepository) FindByName(name string) (*domain.Country, error) {
 
 func (m *mockRepository) FindByCode(code string) (*domain.Country, error) {
 	for _, country := range m.countries {
-		if country.Code == code {
+		if country.ISO2 == code || country.ISO3 == code || country.Numeric == code {
 			return country, nil
 		}
 	}
 	return nil, domain.NewNotFoundError(code)
 }
 
+func (m *mockRepository) FindByCodeIn(system, code string) []*domain.Country {
+	var found []*domain.Country
+	for _, country := range m.countries {
+		if country.CodeIn(system) == code {
+			found = append(found, country)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].ISO2 < found[j].ISO2
+	})
+	return found
+}
+
+func (m *mockRepository) Countries() []*domain.Country {
+	found := make([]*domain.Country, 0, len(m.countries))
+	for _, country := range m.countries {
+		found = append(found, country)
+	}
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].ISO2 < found[j].ISO2
+	})
+	return found
+}
+
+func (m *mockRepository) Aliases(iso2 string) []string {
+	for _, country := range m.countries {
+		if country.ISO2 == iso2 {
+			return country.Aliases
+		}
+	}
+	return nil
+}
+
+func (m *mockRepository) FindHistorical(code string) (*domain.Match, error) {
+	for i := range m.historical {
+		h := &m.historical[i]
+		if h.Code == code || h.ISO2 == code || h.ISO3 == code {
+			return &domain.Match{Country: h.AsCountry(), Type: domain.MatchTypeHistorical, Historical: h}, nil
+		}
+	}
+	return nil, domain.NewNotFoundError(code)
+}
+
+func (m *mockRepository) MatchSubdivision(query, country string) (*domain.Match, error) {
+	for i := range m.subdivisions {
+		subdivision := &m.subdivisions[i]
+		_, local, _ := domain.SplitSubdivisionCode(subdivision.Code)
+		if subdivision.Code != query && local != query && subdivision.GetName() != query {
+			continue
+		}
+		if country != "" && subdivision.Country != country {
+			continue
+		}
+		parent, err := m.FindByCode(subdivision.Country)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.Match{Country: parent, Type: domain.MatchTypeExact, Subdivision: subdivision}, nil
+	}
+	return nil, domain.NewSubdivisionNotFoundError(query)
+}
+
+func (m *mockRepository) Subdivisions(country string) []*domain.Subdivision {
+	var found []*domain.Subdivision
+	for i := range m.subdivisions {
+		if m.subdivisions[i].Country == country {
+			found = append(found, &m.subdivisions[i])
+		}
+	}
+	return found
+}
+
+func (m *mockRepository) MatchByName(name string) (*domain.Match, error) {
+	country, err := m.FindByName(name)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Match{Country: country, Type: domain.MatchTypeExact, MatchedName: name, Score: 1}, nil
+}
+
+func (m *mockRepository) MatchDemonym(name string) (*domain.Match, error) {
+	for _, country := range m.Countries() {
+		for lang, forms := range country.Demonyms {
+			for _, form := range forms {
+				if form.Text == name {
+					return &domain.Match{
+						Country:     country,
+						Type:        domain.MatchTypeDemonym,
+						MatchedName: name,
+						Score:       0.95,
+						Demonyms:    []domain.DemonymInfo{{Demonym: form, Language: lang}},
+					}, nil
+				}
+			}
+		}
+	}
+	return nil, domain.NewNotFoundError(name)
+}
+
+func (m *mockRepository) Suggest(name string, limit int) []*domain.Match {
+	match, err := m.MatchByName(name)
+	if err != nil {
+		return nil
+	}
+	return []*domain.Match{match}
+}
+
+func (m *mockRepository) PostalCodeFormats(country string) []*domain.PostalCodeFormat {
+	var found []*domain.PostalCodeFormat
+	for i := range m.postalCodes {
+		if m.postalCodes[i].Country == country {
+			found = append(found, &m.postalCodes[i])
+		}
+	}
+	return found
+}
+
+func (m *mockRepository) PostalCodeCountries(code string) []*domain.Country {
+	var found []*domain.Country
+	for _, country := range m.Countries() {
+		for _, format := range m.PostalCodeFormats(country.ISO2) {
+			if format.Matches(code) {
+				found = append(found, country)
+				break
+			}
+		}
+	}
+	return found
+}
+
+func (m *mockRepository) Extract(text string) []domain.Extraction {
+	var found []domain.Extraction
+	for _, word := range strings.Fields(text) {
+		if match, err := m.MatchByName(word); err == nil {
+			start := strings.Index(text, word)
+			found = append(found, domain.Extraction{Text: word, Start: start, End: start + len(word), Match: match})
+		}
+	}
+	return found
+}
+
+func (m *mockRepository) Autocomplete(prefix string, limit int) []*domain.Match {
+	return m.Suggest(prefix, limit)
+}
+
 func TestCountryService_LookupCountry(t *testing.T) {
 	// Setup
 	mockRepo := &mockRepository{
 		countries: map[string]*domain.Country{
-			"romania": {Code: "RO", Name: "Romania"},
-			"germany": {Code: "DE", Name: "Germany"},
+			"romania": {ISO2: "RO", ISO3: "ROU", Names: map[string]string{"en": "Romania"}},
+			"germany": {ISO2: "DE", ISO3: "DEU", Names: map[string]string{"en": "Germany"}},
 		},
 	}
 
@@ -85,8 +235,8 @@ func TestCountryService_LookupCountry(t *testing.T) {
 				return
 			}
 
-			if result.ISOCode != tt.expectedCode {
-				t.Errorf("expected ISO code %s, got %s", tt.expectedCode, result.ISOCode)
+			if result.ISO2Code != tt.expectedCode {
+				t.Errorf("expected ISO code %s, got %s", tt.expectedCode, result.ISO2Code)
 			}
 
 			if result.OfficialName != tt.expectedName {
@@ -95,3 +245,673 @@ func TestCountryService_LookupCountry(t *testing.T) {
 		})
 	}
 }
+
+func TestCountryService_LookupBatch(t *testing.T) {
+	mockRepo := &mockRepository{
+		countries: map[string]*domain.Country{
+			"romania": {ISO2: "RO", ISO3: "ROU", Names: map[string]string{"en": "Romania"}},
+		},
+	}
+
+	countryService := service.NewCountryService(mockRepo)
+	response := countryService.LookupBatch([]string{"romania", "unknown", " "}, service.LookupOptions{})
+
+	expected := []string{"ok", "not_found", "validation"}
+	if len(response.Results) != len(expected) {
+		t.Fatalf("expected %d results, got %d", len(expected), len(response.Results))
+	}
+
+	for i, status := range expected {
+		item := response.Results[i]
+		if item.Index != i {
+			t.Errorf("expected index %d, got %d", i, item.Index)
+		}
+		if item.Status != status {
+			t.Errorf("item %d: expected status %s, got %s", i, status, item.Status)
+		}
+	}
+
+	if response.Results[0].Result == nil || response.Results[0].Result.ISO2Code != "RO" {
+		t.Errorf("expected first item to resolve to RO")
+	}
+	if response.Summary.Total != 3 || response.Summary.Status["ok"] != 1 {
+		t.Errorf("unexpected summary: %+v", response.Summary)
+	}
+}
+
+func TestCountryService_GetCountry(t *testing.T) {
+	mockRepo := &mockRepository{
+		countries: map[string]*domain.Country{
+			"germany": {ISO2: "DE", ISO3: "DEU", Numeric: "276", Names: map[string]string{"en": "Germany", "de": "Deutschland"}},
+			"brazil":  {ISO2: "BR", ISO3: "BRA", Numeric: "076", Names: map[string]string{"en": "Brazil", "de": "Brasilien"}},
+		},
+	}
+
+	countryService := service.NewCountryService(mockRepo)
+
+	tests := []struct {
+		name          string
+		code          string
+		expectedCode  string
+		expectedError int
+	}{
+		{name: "alpha-2", code: "DE", expectedCode: "DE"},
+		{name: "alpha-3 lowercase", code: " deu ", expectedCode: "DE"},
+		{name: "numeric", code: "276", expectedCode: "DE"},
+		{name: "numeric without leading zero", code: "76", expectedCode: "BR"},
+		{name: "numeric with extra leading zero", code: "0076", expectedCode: "BR"},
+		{name: "numeric too long", code: "1276", expectedError: 400},
+		{name: "unknown code", code: "XX", expectedError: 404},
+		{name: "invalid length", code: "GERMAN", expectedError: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := countryService.GetCountry(tt.code, service.LookupOptions{Languages: []string{"de"}})
+
+			if tt.expectedError != 0 {
+				appErr, ok := err.(*domain.AppError)
+				if !ok || appErr.Code != tt.expectedError {
+					t.Errorf("expected error code %d, got %v", tt.expectedError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.ISO2Code != tt.expectedCode {
+				t.Errorf("expected ISO code %s, got %s", tt.expectedCode, result.ISO2Code)
+			}
+			if result.MatchType != string(domain.MatchTypeCode) || result.LocalizedName == "" || result.NumericCode == "" {
+				t.Errorf("unexpected response: %+v", result)
+			}
+		})
+	}
+}
+
+func TestCountryService_IncludeMeta(t *testing.T) {
+	mockRepo := &mockRepository{
+		countries: map[string]*domain.Country{
+			"switzerland": {ISO2: "CH", ISO3: "CHE", Numeric: "756", Names: map[string]string{"en": "Switzerland"},
+				Codes: map[string]string{"itu": "41", "tld": ".ch"},
+				Meta:  &domain.CountryMeta{Capital: "Bern", Currencies: []string{"CHF"}, Languages: []string{"de", "fr", "it", "rm"}}},
+			"nowhere": {ISO2: "XN", ISO3: "XNW", Names: map[string]string{"en": "Nowhere"}},
+		},
+	}
+
+	countryService := service.NewCountryService(mockRepo)
+
+	result, err := countryService.Lookup("switzerland", service.LookupOptions{})
+	if err != nil || result.Meta != nil {
+		t.Errorf("expected no metadata without IncludeMeta, got %+v, %v", result, err)
+	}
+
+	result, err = countryService.Lookup("switzerland", service.LookupOptions{IncludeMeta: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if meta := result.Meta; meta == nil || meta.Capital != "Bern" || meta.CallingCode != "41" || meta.TLD != ".ch" || len(meta.Languages) != 4 {
+		t.Errorf("unexpected metadata: %+v", result.Meta)
+	}
+
+	result, err = countryService.GetCountry("CHE", service.LookupOptions{IncludeMeta: true})
+	if err != nil || result.Meta == nil || result.Meta.Currencies[0] != "CHF" {
+		t.Errorf("expected metadata from GetCountry, got %+v, %v", result, err)
+	}
+
+	result, err = countryService.GetCountry("XN", service.LookupOptions{IncludeMeta: true})
+	if err != nil || result.Meta != nil {
+		t.Errorf("expected no metadata for a country without any, got %+v, %v", result, err)
+	}
+}
+
+func TestCountryService_GetCountryHistorical(t *testing.T) {
+	mockRepo := &mockRepository{
+		countries: map[string]*domain.Country{
+			"russia": {ISO2: "RU", ISO3: "RUS", Numeric: "643", Names: map[string]string{"en": "Russia"}},
+		},
+		historical: []domain.HistoricalCountry{
+			{Code: "SUHH", ISO2: "SU", ISO3: "SUN", Numeric: "810", Names: map[string]string{"en": "USSR"}, ValidTo: "1992", Successors: []string{"RU"}},
+		},
+	}
+
+	countryService := service.NewCountryService(mockRepo)
+
+	tests := []struct {
+		name          string
+		code          string
+		expectedError int
+	}{
+		{name: "ISO 3166-3 code", code: "suhh"},
+		{name: "former alpha-2", code: "SU"},
+		{name: "former alpha-3", code: "SUN"},
+		{name: "unknown ISO 3166-3 code", code: "XXHH", expectedError: 404},
+		{name: "not a code", code: "SU1H", expectedError: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := countryService.GetCountry(tt.code, service.LookupOptions{})
+
+			if tt.expectedError != 0 {
+				appErr, ok := err.(*domain.AppError)
+				if !ok || appErr.Code != tt.expectedError {
+					t.Errorf("expected error code %d, got %v", tt.expectedError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.MatchType != string(domain.MatchTypeHistorical) || result.ISO2Code != "SU" {
+				t.Errorf("unexpected response: %+v", result)
+			}
+			if result.Historical == nil || result.Historical.Code != "SUHH" || result.Historical.ValidTo != "1992" {
+				t.Errorf("expected historical details, got %+v", result.Historical)
+			}
+		})
+	}
+}
+
+func TestCountryService_ConvertCode(t *testing.T) {
+	mockRepo := &mockRepository{
+		countries: map[string]*domain.Country{
+			"germany": {ISO2: "DE", ISO3: "DEU", Numeric: "276", Names: map[string]string{"en": "Germany"},
+				Codes: map[string]string{"ioc": "GER", "itu": "49", "tld": ".de"}},
+			"united states": {ISO2: "US", ISO3: "USA", Numeric: "840", Names: map[string]string{"en": "United States"},
+				Codes: map[string]string{"ioc": "USA", "itu": "1"}},
+			"canada": {ISO2: "CA", ISO3: "CAN", Numeric: "124", Names: map[string]string{"en": "Canada"},
+				Codes: map[string]string{"ioc": "CAN", "itu": "1"}},
+		},
+	}
+
+	countryService := service.NewCountryService(mockRepo)
+
+	tests := []struct {
+		name           string
+		from, to, code string
+		expected       string
+		expectedError  int
+		candidates     int
+	}{
+		{name: "ioc to iso2", from: "ioc", to: "iso2", code: "GER", expected: "DE"},
+		{name: "case and whitespace", from: " IOC ", to: "ISO3", code: "ger", expected: "DEU"},
+		{name: "iso2 to tld", from: "iso2", to: "tld", code: "de", expected: ".de"},
+		{name: "calling code with plus", from: "itu", to: "iso2", code: "+49", expected: "DE"},
+		{name: "numeric to m49", from: "numeric", to: "m49", code: "276", expected: "276"},
+		{name: "tld without dot", from: "tld", to: "ioc", code: "DE", expected: "GER"},
+		{name: "code shared by several countries", from: "itu", to: "iso2", code: "1", expectedError: 409, candidates: 2},
+		{name: "missing in target system", from: "iso2", to: "tld", code: "US", expectedError: 404},
+		{name: "unknown code", from: "ioc", to: "iso2", code: "XYZ", expectedError: 404},
+		{name: "unknown system", from: "nato", to: "iso2", code: "DEU", expectedError: 400},
+		{name: "invalid code", from: "numeric", to: "iso2", code: "12a", expectedError: 400},
+		{name: "missing code", from: "ioc", to: "iso2", expectedError: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := countryService.ConvertCode(tt.from, tt.to, tt.code, service.LookupOptions{})
+
+			if tt.expectedError != 0 {
+				appErr, ok := err.(*domain.AppError)
+				if !ok || appErr.Code != tt.expectedError {
+					t.Fatalf("expected error code %d, got %v", tt.expectedError, err)
+				}
+				if len(appErr.Candidates) != tt.candidates {
+					t.Errorf("expected %d candidates, got %+v", tt.candidates, appErr.Candidates)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Result != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, result.Result)
+			}
+		})
+	}
+}
+
+func TestCountryService_Subdivisions(t *testing.T) {
+	mockRepo := &mockRepository{
+		countries: map[string]*domain.Country{
+			"germany": {ISO2: "DE", ISO3: "DEU", Numeric: "276", Names: map[string]string{"en": "Germany", "de": "Deutschland"}},
+			"austria": {ISO2: "AT", ISO3: "AUT", Numeric: "040", Names: map[string]string{"en": "Austria"}},
+		},
+		subdivisions: []domain.Subdivision{
+			{Code: "DE-BE", Country: "DE", Type: "state", Names: map[string]string{"en": "Berlin"}},
+			{Code: "DE-BY", Country: "DE", Type: "state", Names: map[string]string{"en": "Bavaria", "de": "Bayern"}},
+		},
+	}
+
+	countryService := service.NewCountryService(mockRepo)
+
+	tests := []struct {
+		name          string
+		query         string
+		country       string
+		expectedError int
+	}{
+		{name: "name", query: "Bavaria"},
+		{name: "code within country", query: "DE-BY", country: "deu"},
+		{name: "other country", query: "Bavaria", country: "AT", expectedError: 404},
+		{name: "unknown country", query: "Bavaria", country: "XX", expectedError: 404},
+		{name: "invalid country", query: "Bavaria", country: "Germany", expectedError: 400},
+		{name: "empty query", query: " ", expectedError: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := countryService.LookupSubdivision(tt.query, tt.country, service.LookupOptions{Languages: []string{"de"}})
+
+			if tt.expectedError != 0 {
+				appErr, ok := err.(*domain.AppError)
+				if !ok || appErr.Code != tt.expectedError {
+					t.Errorf("expected error code %d, got %v", tt.expectedError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Subdivision.Code != "DE-BY" || result.Subdivision.LocalizedName != "Bayern" {
+				t.Errorf("unexpected subdivision: %+v", result.Subdivision)
+			}
+			if result.Country.ISO2Code != "DE" || result.Country.LocalizedName != "Deutschland" {
+				t.Errorf("unexpected country: %+v", result.Country)
+			}
+		})
+	}
+
+	list, err := countryService.ListSubdivisions("276", service.LookupOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list.Subdivisions) != 2 || list.Subdivisions[0].Code != "DE-BE" || list.Country.ISO2Code != "DE" {
+		t.Errorf("unexpected subdivision list: %+v", list)
+	}
+
+	list, err = countryService.ListSubdivisions("AT", service.LookupOptions{})
+	if err != nil || len(list.Subdivisions) != 0 {
+		t.Errorf("expected no subdivisions for AT, got %+v, %v", list, err)
+	}
+}
+
+func TestCountryService_ListCountries(t *testing.T) {
+	mockRepo := &mockRepository{
+		countries: map[string]*domain.Country{
+			"austria": {ISO2: "AT", ISO3: "AUT", Numeric: "040", Names: map[string]string{"en": "Austria", "de": "Österreich"},
+				Meta: &domain.CountryMeta{Continent: "Europe", Region: "Europe", Subregion: "Western Europe", Capital: "Vienna"}},
+			"germany": {ISO2: "DE", ISO3: "DEU", Numeric: "276", Names: map[string]string{"en": "Germany", "de": "Deutschland"},
+				Meta: &domain.CountryMeta{Continent: "Europe", Region: "Europe", Subregion: "Western Europe", Capital: "Berlin"}},
+			"egypt": {ISO2: "EG", ISO3: "EGY", Numeric: "818", Names: map[string]string{"en": "Egypt"},
+				Meta: &domain.CountryMeta{Continent: "Africa", Region: "Africa", Subregion: "Northern Africa", Capital: "Cairo"}},
+			"bermuda": {ISO2: "BM", ISO3: "BMU", Numeric: "060", Names: map[string]string{"en": "Bermuda"}},
+		},
+	}
+
+	countryService := service.NewCountryService(mockRepo)
+
+	tests := []struct {
+		name          string
+		query         service.CatalogQuery
+		opts          service.LookupOptions
+		expectedTotal int
+		expectedCodes []string
+		expectedError bool
+	}{
+		{
+			name:          "all countries by ISO2",
+			expectedTotal: 4,
+			expectedCodes: []string{"AT", "BM", "DE", "EG"},
+		},
+		{
+			name:          "region matches continent, region or subregion ignoring case",
+			query:         service.CatalogQuery{Region: "western europe"},
+			expectedTotal: 2,
+			expectedCodes: []string{"AT", "DE"},
+		},
+		{
+			name:          "language coverage",
+			query:         service.CatalogQuery{Language: "de-AT"},
+			expectedTotal: 2,
+			expectedCodes: []string{"AT", "DE"},
+		},
+		{
+			name:          "numeric descending",
+			query:         service.CatalogQuery{Sort: service.CatalogSortNumeric, Descending: true},
+			expectedTotal: 4,
+			expectedCodes: []string{"EG", "DE", "BM", "AT"},
+		},
+		{
+			name:          "localized names sort by collation",
+			query:         service.CatalogQuery{Sort: service.CatalogSortName},
+			opts:          service.LookupOptions{Languages: []string{"de"}},
+			expectedTotal: 4,
+			expectedCodes: []string{"BM", "DE", "EG", "AT"},
+		},
+		{
+			name:          "pagination",
+			query:         service.CatalogQuery{Offset: 1, Limit: 2},
+			expectedTotal: 4,
+			expectedCodes: []string{"BM", "DE"},
+		},
+		{
+			name:          "offset past the end",
+			query:         service.CatalogQuery{Offset: 10},
+			expectedTotal: 4,
+			expectedCodes: []string{},
+		},
+		{
+			name:          "unknown sort key",
+			query:         service.CatalogQuery{Sort: "capital"},
+			expectedError: true,
+		},
+		{
+			name:          "limit too large",
+			query:         service.CatalogQuery{Limit: service.MaxCatalogLimit + 1},
+			expectedError: true,
+		},
+		{
+			name:          "invalid language tag",
+			query:         service.CatalogQuery{Language: "not a tag"},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := countryService.ListCountries(tt.query, tt.opts)
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Total != tt.expectedTotal {
+				t.Errorf("expected total %d, got %d", tt.expectedTotal, result.Total)
+			}
+			codes := []string{}
+			for _, entry := range result.Countries {
+				codes = append(codes, entry.ISO2Code)
+			}
+			if strings.Join(codes, ",") != strings.Join(tt.expectedCodes, ",") {
+				t.Errorf("expected countries %v, got %v", tt.expectedCodes, codes)
+			}
+		})
+	}
+
+	result, err := countryService.ListCountries(service.CatalogQuery{Region: "Africa"}, service.LookupOptions{IncludeMeta: true})
+	if err != nil || len(result.Countries) != 1 || result.Countries[0].Meta == nil || result.Countries[0].Meta.Capital != "Cairo" {
+		t.Errorf("expected metadata with IncludeMeta, got %+v, %v", result, err)
+	}
+}
+
+func TestCountryService_GetCountryRecord(t *testing.T) {
+	mockRepo := &mockRepository{
+		countries: map[string]*domain.Country{
+			"germany": {ISO2: "DE", ISO3: "DEU", Numeric: "276", Names: map[string]string{"en": "Germany", "de": "Deutschland"},
+				Aliases: []string{"Federal Republic of Germany", "BRD"}, Codes: map[string]string{"ioc": "GER"},
+				Meta: &domain.CountryMeta{Capital: "Berlin"}},
+		},
+	}
+
+	countryService := service.NewCountryService(mockRepo)
+
+	for _, code := range []string{"DE", "DEU", "276"} {
+		record, err := countryService.GetCountryRecord(code, service.LookupOptions{Languages: []string{"de"}})
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", code, err)
+		}
+		if record.ISO2Code != "DE" || record.LocalizedName != "Deutschland" || len(record.Names) != 2 ||
+			len(record.Aliases) != 2 || record.Codes["ioc"] != "GER" || record.Meta == nil || record.Meta.Capital != "Berlin" {
+			t.Errorf("unexpected record for %s: %+v", code, record)
+		}
+	}
+
+	if _, err := countryService.GetCountryRecord("XX", service.LookupOptions{}); err == nil {
+		t.Error("expected an error for an unknown code")
+	}
+	if _, err := countryService.GetCountryRecord("Germany", service.LookupOptions{}); err == nil {
+		t.Error("expected an error for a name")
+	}
+}
+
+func TestCountryService_ExtractCountries(t *testing.T) {
+	mockRepo := &mockRepository{
+		countries: map[string]*domain.Country{
+			"Germany":     {ISO2: "DE", ISO3: "DEU", Names: map[string]string{"en": "Germany", "fr": "Allemagne"}},
+			"Deutschland": {ISO2: "DE", ISO3: "DEU", Names: map[string]string{"en": "Germany", "fr": "Allemagne"}},
+			"Brazil":      {ISO2: "BR", ISO3: "BRA", Names: map[string]string{"en": "Brazil"}},
+		},
+	}
+
+	countryService := service.NewCountryService(mockRepo)
+
+	result, err := countryService.ExtractCountries("Germany Brazil Deutschland", service.LookupOptions{Languages: []string{"fr"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Mentions) != 3 || result.Mentions[0].Country.LocalizedName != "Allemagne" || result.Mentions[1].Start != 8 {
+		t.Errorf("unexpected mentions: %+v", result.Mentions)
+	}
+	if strings.Join(result.Countries, ",") != "DE,BR" {
+		t.Errorf("expected countries in order of first mention, got %v", result.Countries)
+	}
+
+	result, err = countryService.ExtractCountries("nothing here", service.LookupOptions{})
+	if err != nil || result.Mentions == nil || len(result.Mentions) != 0 || len(result.Countries) != 0 {
+		t.Errorf("expected empty lists, got %+v, %v", result, err)
+	}
+
+	if _, err := countryService.ExtractCountries("   ", service.LookupOptions{}); err == nil {
+		t.Error("expected an error for an empty text")
+	}
+}
+
+func TestCountryService_ResolveAddress(t *testing.T) {
+	mockRepo := &mockRepository{
+		countries: map[string]*domain.Country{
+			"Romania": {ISO2: "RO", ISO3: "ROU", Names: map[string]string{"en": "Romania"}},
+			"Georgia": {ISO2: "GE", ISO3: "GEO", Names: map[string]string{"en": "Georgia"}},
+			"USA":     {ISO2: "US", ISO3: "USA", Names: map[string]string{"en": "United States of America"}},
+			"Germany": {ISO2: "DE", ISO3: "DEU", Names: map[string]string{"en": "Germany"}},
+			"CA":      {ISO2: "CA", ISO3: "CAN", Names: map[string]string{"en": "Canada"}},
+		},
+		postalCodes: []domain.PostalCodeFormat{
+			{Country: "RO", Pattern: `\d{6}`},
+			{Country: "US", Pattern: `\d{5}([ -]\d{4})?`},
+			{Country: "DE", Pattern: `\d{5}`},
+			{Country: "CA", Pattern: `[A-Z]\d[A-Z] ?\d[A-Z]\d`},
+		},
+		subdivisions: []domain.Subdivision{
+			{Code: "US-CA", Country: "US", Type: "state", Names: map[string]string{"en": "California"}},
+			{Code: "DE-BE", Country: "DE", Type: "state", Names: map[string]string{"en": "Berlin"}},
+		},
+	}
+	for i := range mockRepo.postalCodes {
+		if err := mockRepo.postalCodes[i].Compile(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	countryService := service.NewCountryService(mockRepo)
+
+	tests := []struct {
+		name           string
+		address        string
+		expectedCode   string
+		expectedPostal string
+		candidates     int
+		expectedError  int
+	}{
+		{name: "country on the last line", address: "Str. Victoriei 10\n010011 Bucharest\nRomania", expectedCode: "RO", expectedPostal: "010011", candidates: 1},
+		{name: "postal code outweighs a closer mention", address: "1 Peachtree St\nAtlanta Georgia 30303-1234\nUSA", expectedCode: "US", expectedPostal: "30303-1234", candidates: 2},
+		{name: "one-line address", address: "1 Peachtree St, Atlanta, Georgia", expectedCode: "GE", candidates: 1},
+		{name: "unique postal code", address: "Str. Victoriei 10\n010011 Bucharest", expectedCode: "RO", expectedPostal: "010011", candidates: 1},
+		{name: "postal code of several countries", address: "Main Street 5\n10115", expectedCode: "DE", expectedPostal: "10115", candidates: 2},
+		{name: "subdivision ranks a shared postal code", address: "Hauptstr. 5\n10115 Berlin", expectedCode: "DE", expectedPostal: "10115", candidates: 2},
+		{name: "postal code overrules a rejecting mention", address: "1600 Amphitheatre Pkwy\nMountain View, CA 94043", expectedCode: "US", expectedPostal: "94043", candidates: 3},
+		{name: "number above the country is no postal code", address: "10115 Main Street\nRomania", expectedCode: "RO", candidates: 1},
+		{name: "nothing to go by", address: "Main Street\nSpringfield", expectedError: 404},
+		{name: "empty address", address: " \n ", expectedError: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := countryService.ResolveAddress(tt.address, service.LookupOptions{})
+			if tt.expectedError != 0 {
+				appErr, ok := err.(*domain.AppError)
+				if !ok || appErr.Code != tt.expectedError {
+					t.Fatalf("expected error code %d, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Country.ISO2Code != tt.expectedCode || result.PostalCode != tt.expectedPostal {
+				t.Errorf("expected %s with postal code %q, got %s with %q", tt.expectedCode, tt.expectedPostal, result.Country.ISO2Code, result.PostalCode)
+			}
+			if len(result.Candidates) != tt.candidates || len(result.Explanation) == 0 {
+				t.Errorf("expected %d explained candidates, got %+v", tt.candidates, result.Candidates)
+			}
+			if result.Confidence <= 0 || result.Confidence > 1 {
+				t.Errorf("expected a confidence in (0, 1], got %v", result.Confidence)
+			}
+		})
+	}
+}
+
+func TestCountryService_LookupNationality(t *testing.T) {
+	germany := &domain.Country{
+		ISO2: "DE", ISO3: "DEU", Names: map[string]string{"en": "Germany"},
+		Demonyms: map[string][]domain.Demonym{
+			"en": {{Text: "German"}, {Text: "Germans", Plural: true}},
+			"de": {{Text: "Deutscher", Gender: domain.GenderMasculine}, {Text: "deutsch"}},
+		},
+	}
+	mockRepo := &mockRepository{
+		countries: map[string]*domain.Country{"Germany": germany},
+	}
+
+	countryService := service.NewCountryService(mockRepo)
+	nationality := service.LookupOptions{Kind: service.KindNationality}
+
+	tests := []struct {
+		name          string
+		query         string
+		opts          service.LookupOptions
+		expectedType  string
+		expectedError int
+	}{
+		{name: "demonym", query: "Deutscher", opts: nationality, expectedType: "demonym"},
+		{name: "adjective", query: "deutsch", opts: nationality, expectedType: "demonym"},
+		{name: "country name fallback", query: "Germany", opts: nationality, expectedType: "exact"},
+		{name: "demonym in country mode", query: "German", opts: service.LookupOptions{}, expectedError: 404},
+		{name: "unknown", query: "Martian", opts: nationality, expectedError: 404},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := countryService.Lookup(tt.query, tt.opts)
+			if tt.expectedError != 0 {
+				appErr, ok := err.(*domain.AppError)
+				if !ok || appErr.Code != tt.expectedError {
+					t.Fatalf("expected error code %d, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.ISO2Code != "DE" || result.MatchType != tt.expectedType {
+				t.Errorf("expected DE (%s), got %s (%s)", tt.expectedType, result.ISO2Code, result.MatchType)
+			}
+			if (tt.expectedType == "demonym") != (len(result.Demonyms) > 0) {
+				t.Errorf("expected demonyms only for demonym matches, got %+v", result.Demonyms)
+			}
+		})
+	}
+
+	detailed, err := countryService.LookupDetailed("Deutscher", nationality)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(detailed.Demonyms) != 1 || detailed.Demonyms[0].Language != "de" || detailed.Demonyms[0].Gender != domain.GenderMasculine {
+		t.Errorf("unexpected demonyms: %+v", detailed.Demonyms)
+	}
+}
+
+func TestCountryService_ResolveLocale(t *testing.T) {
+	mockRepo := &mockRepository{
+		countries: map[string]*domain.Country{
+			"Brazil":  {ISO2: "BR", ISO3: "BRA", Names: map[string]string{"en": "Brazil", "de": "Brasilien"}},
+			"Taiwan":  {ISO2: "TW", ISO3: "TWN", Names: map[string]string{"en": "Taiwan"}},
+			"Germany": {ISO2: "DE", ISO3: "DEU", Names: map[string]string{"en": "Germany"}},
+		},
+	}
+
+	countryService := service.NewCountryService(mockRepo)
+
+	tests := []struct {
+		name           string
+		identifier     string
+		likely         bool
+		expectedCode   string
+		expectedRegion string
+		expectedLikely bool
+		expectedError  int
+	}{
+		{name: "POSIX locale", identifier: "pt_BR.UTF-8", expectedCode: "BR"},
+		{name: "script subtag", identifier: "zh-Hant-TW", expectedCode: "TW"},
+		{name: "macro region", identifier: "es-419", expectedRegion: "419"},
+		{name: "likely country", identifier: "de", likely: true, expectedCode: "DE", expectedLikely: true},
+		{name: "no region", identifier: "de", expectedError: 404},
+		{name: "country not in the data", identifier: "fr-FR", expectedError: 404},
+		{name: "invalid", identifier: "not a locale", expectedError: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := countryService.ResolveLocale(tt.identifier, service.LookupOptions{Languages: []string{"de"}, Likely: tt.likely})
+			if tt.expectedError != 0 {
+				appErr, ok := err.(*domain.AppError)
+				if !ok || appErr.Code != tt.expectedError {
+					t.Fatalf("expected error code %d, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expectedRegion != "" {
+				if result.Region == nil || result.Region.Code != tt.expectedRegion || result.Country != nil {
+					t.Errorf("expected region %s, got %+v", tt.expectedRegion, result)
+				}
+				return
+			}
+			if result.Country == nil || result.Country.ISO2Code != tt.expectedCode || result.Likely != tt.expectedLikely {
+				t.Errorf("expected %s (likely %v), got %+v", tt.expectedCode, tt.expectedLikely, result)
+			}
+		})
+	}
+
+	batch := countryService.LookupBatch([]string{"pt_BR", "es-419", "xx-!"}, service.LookupOptions{Kind: service.KindLocale})
+	if batch.Summary.Status["ok"] != 1 || batch.Summary.Status[domain.BatchStatusRegion] != 1 || batch.Summary.Status["validation"] != 1 {
+		t.Errorf("unexpected summary: %+v", batch.Summary)
+	}
+	if result := batch.Results[0].Result; result == nil || result.ISO2Code != "BR" || result.MatchType != "locale" {
+		t.Errorf("expected BR (locale), got %+v", result)
+	}
+	if region := batch.Results[1].Region; region == nil || region.Name != "Latin America" {
+		t.Errorf("expected Latin America, got %+v", region)
+	}
+}
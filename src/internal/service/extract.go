@@ -0,0 +1,37 @@
+package service
+
+import (
+	"strings"
+
+	"country-iso-matcher/src/internal/domain"
+	"country-iso-matcher/src/pkg/locale"
+)
+
+// ExtractCountries finds every country mentioned in a free text such as a sentence or an
+// address. Country and subdivision names are localized for opts.Languages.
+func (s *countryService) ExtractCountries(text string, opts LookupOptions) (*domain.ExtractResponse, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, domain.NewValidationError("Text is required", "")
+	}
+
+	var chain []string
+	if len(opts.Languages) > 0 {
+		chain = locale.FallbackChain(opts.Languages, DefaultLanguage)
+	}
+
+	response := &domain.ExtractResponse{
+		Mentions:  []domain.Mention{},
+		Countries: []string{},
+	}
+	seen := make(map[string]bool)
+	for _, extraction := range s.repository.Extract(text) {
+		response.Mentions = append(response.Mentions, domain.NewMention(extraction, chain))
+
+		if code := extraction.Match.Country.ISO2; !seen[code] {
+			seen[code] = true
+			response.Countries = append(response.Countries, code)
+		}
+	}
+
+	return response, nil
+}
@@ -2,6 +2,52 @@ package service
 
 import "country-iso-matcher/src/internal/domain"
 
+// LookupKind is what the query of a lookup names
+type LookupKind string
+
+const (
+	// KindCountry queries name a country: names, aliases and codes match (the default)
+	KindCountry LookupKind = "country"
+	// KindNationality queries name a nationality: demonyms such as "German" match first,
+	// then everything KindCountry matches
+	KindNationality LookupKind = "nationality"
+	// KindLocale queries are locale identifiers such as "pt_BR.UTF-8" or "zh-Hant-TW",
+	// resolved through their region subtag
+	KindLocale LookupKind = "locale"
+)
+
+// LookupOptions tunes a single country lookup
+type LookupOptions struct {
+	// Languages are the preferred BCP 47 tags for the localized name, most preferred first.
+	// When empty, the response carries no localized name.
+	Languages []string
+
+	// IncludeMeta adds the country's metadata block (region, capital, currencies, ...) to the response
+	IncludeMeta bool
+
+	// Kind is what the query names; empty means KindCountry
+	Kind LookupKind
+
+	// Likely lets KindLocale lookups fall back to the likely country of the locale's
+	// language when it has no region subtag (de → DE)
+	Likely bool
+}
+
 type CountryService interface {
 	LookupCountry(query string) (*domain.CountryResponse, error)
+	Lookup(query string, opts LookupOptions) (*domain.CountryResponse, error)
+	LookupDetailed(query string, opts LookupOptions) (*domain.CountryResponseV2, error)
+	LookupBatch(queries []string, opts LookupOptions) *domain.BatchResponse
+	LookupItem(index int, query string, opts LookupOptions) domain.BatchItem
+	GetCountry(code string, opts LookupOptions) (*domain.CountryResponse, error)
+	GetCountryRecord(code string, opts LookupOptions) (*domain.CountryRecord, error)
+	ListCountries(query CatalogQuery, opts LookupOptions) (*domain.CatalogResponse, error)
+	LookupSubdivision(query, country string, opts LookupOptions) (*domain.SubdivisionResponse, error)
+	ListSubdivisions(country string, opts LookupOptions) (*domain.SubdivisionListResponse, error)
+	ConvertCode(from, to, code string, opts LookupOptions) (*domain.CodeConversionResponse, error)
+	ResolveAddress(address string, opts LookupOptions) (*domain.AddressResponse, error)
+	ResolveLocale(identifier string, opts LookupOptions) (*domain.LocaleResponse, error)
+	ExtractCountries(text string, opts LookupOptions) (*domain.ExtractResponse, error)
+	SuggestCountries(query string, limit int) (*domain.SuggestResponse, error)
+	AutocompleteCountries(prefix string, languages []string, limit int) (*domain.AutocompleteResponse, error)
 }
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"country-iso-matcher/src/internal/domain"
+	"country-iso-matcher/src/pkg/locale"
+)
+
+const (
+	// scoreLocaleRegion is the confidence of a locale whose region subtag names the country
+	scoreLocaleRegion = 0.9
+	// scoreLikelyRegion is the confidence of a country inferred from the locale's language
+	scoreLikelyRegion = 0.5
+)
+
+// ResolveLocale finds the country of a BCP 47 or POSIX locale identifier from its region
+// subtag. Locales naming a UN M49 area (es-419) resolve to the area instead of a country.
+// Without a region subtag, opts.Likely falls back to the likely country of the language.
+func (s *countryService) ResolveLocale(identifier string, opts LookupOptions) (*domain.LocaleResponse, error) {
+	identifier = strings.TrimSpace(identifier)
+	id, err := parseLocale(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.LocaleResponse{
+		Query:    identifier,
+		Tag:      id.Tag,
+		Language: id.Language,
+		Script:   id.Script,
+	}
+	if id.MacroRegion {
+		response.Region = &domain.RegionInfo{Code: id.Region, Name: id.RegionName}
+		return response, nil
+	}
+
+	country, likely, err := s.localeCountry(identifier, id, opts.Likely)
+	if err != nil {
+		return nil, err
+	}
+
+	info := domain.NewCountryInfo(country)
+	if len(opts.Languages) > 0 {
+		info.Localize(country, locale.FallbackChain(opts.Languages, DefaultLanguage))
+	}
+	response.Country = &info
+	response.Likely = likely
+	return response, nil
+}
+
+// matchLocale resolves a locale identifier query to a country match. Locales naming a
+// UN M49 area are not found, as they name no single country.
+func (s *countryService) matchLocale(query string, likely bool) (*domain.Match, error) {
+	id, err := parseLocale(query)
+	if err != nil {
+		return nil, err
+	}
+	if id.MacroRegion {
+		return nil, &domain.AppError{
+			Code:    404,
+			Message: fmt.Sprintf("Locale %s names the region %s (%s), not a country", id.Tag, id.RegionName, id.Region),
+			Query:   query,
+		}
+	}
+
+	country, inferred, err := s.localeCountry(query, id, likely)
+	if err != nil {
+		return nil, err
+	}
+
+	score := scoreLocaleRegion
+	if inferred {
+		score = scoreLikelyRegion
+	}
+	return &domain.Match{
+		Country:         country,
+		Type:            domain.MatchTypeLocale,
+		NormalizedQuery: id.Tag,
+		MatchedName:     strings.ToLower(country.ISO2),
+		Provenance:      []domain.Provenance{{Source: domain.MatchSourceISO2, Original: country.ISO2}},
+		Score:           score,
+	}, nil
+}
+
+// localeCountry finds the country of a parsed locale's region subtag, or of its likely
+// region when likely is set. It reports whether the country was inferred.
+func (s *countryService) localeCountry(query string, id locale.Identifier, likely bool) (*domain.Country, bool, error) {
+	code, inferred := id.Region, false
+	if code == "" {
+		if !likely || id.LikelyRegion == "" {
+			return nil, false, &domain.AppError{
+				Code:    404,
+				Message: fmt.Sprintf("Locale %s has no region subtag", id.Tag),
+				Query:   query,
+			}
+		}
+		code, inferred = id.LikelyRegion, true
+	}
+
+	country, err := s.repository.FindByCode(code)
+	if err != nil {
+		return nil, false, err
+	}
+	return country, inferred, nil
+}
+
+// parseLocale parses a trimmed locale identifier query
+func parseLocale(query string) (locale.Identifier, error) {
+	if query == "" {
+		return locale.Identifier{}, domain.NewValidationError("Locale parameter is required", query)
+	}
+
+	id, err := locale.ParseIdentifier(query)
+	if err != nil {
+		return locale.Identifier{}, domain.NewValidationError(err.Error(), query)
+	}
+	return id, nil
+}
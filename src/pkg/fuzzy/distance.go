@@ -0,0 +1,61 @@
+package fuzzy
+
+// Distance returns the Damerau-Levenshtein distance (optimal string alignment variant)
+// between a and b. Insertions, deletions, substitutions and transpositions of two
+// adjacent characters each cost 1. The comparison is done on runes, not bytes.
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	la, lb := len(ra), len(rb)
+
+	if la == 0 {
+		return lb
+	}
+	if lb == 0 {
+		return la
+	}
+
+	// Keep only the last three rows of the matrix
+	prev2 := make([]int, lb+1)
+	prev := make([]int, lb+1)
+	curr := make([]int, lb+1)
+	for j := 0; j <= lb; j++ {
+		prev[j] = j
+	}
+
+	for i := 1; i <= la; i++ {
+		curr[0] = i
+		for j := 1; j <= lb; j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(
+				prev[j]+1,      // deletion
+				curr[j-1]+1,    // insertion
+				prev[j-1]+cost, // substitution
+			)
+
+			// Transposition of two adjacent characters
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	return prev[lb]
+}
+
+// WithinDistance reports whether the distance between a and b is at most maxDistance.
+// It rejects candidates whose length difference alone exceeds the threshold
+// before running the full computation.
+func WithinDistance(a, b string, maxDistance int) (int, bool) {
+	la, lb := len([]rune(a)), len([]rune(b))
+	if diff := la - lb; diff > maxDistance || -diff > maxDistance {
+		return 0, false
+	}
+
+	d := Distance(a, b)
+	return d, d <= maxDistance
+}
@@ -0,0 +1,44 @@
+package fuzzy_test
+
+import (
+	"testing"
+
+	"country-iso-matcher/src/pkg/fuzzy"
+)
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected int
+	}{
+		{name: "identical", a: "germany", b: "germany", expected: 0},
+		{name: "empty left", a: "", b: "chad", expected: 4},
+		{name: "empty right", a: "peru", b: "", expected: 4},
+		{name: "adjacent transposition", a: "germnay", b: "germany", expected: 1},
+		{name: "substitution", a: "rumania", b: "romania", expected: 1},
+		{name: "insertion and deletion", a: "phillipines", b: "philippines", expected: 2},
+		{name: "multibyte runes", a: "españa", b: "espana", expected: 1},
+		{name: "completely different", a: "chad", b: "peru", expected: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := fuzzy.Distance(tt.a, tt.b)
+			if result != tt.expected {
+				t.Errorf("expected distance %d, got %d", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestWithinDistance(t *testing.T) {
+	if _, ok := fuzzy.WithinDistance("germnay", "germany", 1); !ok {
+		t.Errorf("expected germnay to be within distance 1 of germany")
+	}
+
+	if _, ok := fuzzy.WithinDistance("uk", "united kingdom", 2); ok {
+		t.Errorf("expected length difference to exceed threshold")
+	}
+}
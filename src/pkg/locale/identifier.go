@@ -0,0 +1,66 @@
+package locale
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+// Identifier is a locale identifier broken into the subtags that locate it
+type Identifier struct {
+	Tag      string // Canonical BCP 47 form, e.g. "pt-BR" for "pt_BR.UTF-8"
+	Language string // Language subtag; empty for "und"
+	Script   string // Script subtag when the identifier has one, e.g. "Hant"
+	Region   string // Region subtag when the identifier has one: an ISO 3166-1 alpha-2 code or a UN M49 area code
+
+	// MacroRegion is set when Region is a UN M49 area grouping several countries, e.g. 419
+	// for Latin America. RegionName is its English name.
+	MacroRegion bool
+	RegionName  string
+
+	// LikelyRegion is the country most likely meant when the identifier has no region
+	// subtag, from the CLDR likely subtags of its language and script (de → DE, zh-Hant → TW)
+	LikelyRegion string
+}
+
+// ParseIdentifier parses a BCP 47 language tag (en-US, es-419, zh-Hant-TW) or a POSIX
+// locale (pt_BR.UTF-8, de_DE@euro). The POSIX codeset and modifier are ignored; numeric
+// country codes and deprecated regions are canonicalized (pt-076 → pt-BR, de-DD → de-DE).
+func ParseIdentifier(value string) (Identifier, error) {
+	value = strings.TrimSpace(value)
+	if i := strings.IndexAny(value, ".@"); i >= 0 {
+		value = value[:i]
+	}
+	value = strings.ReplaceAll(value, "_", "-")
+	if value == "" || strings.EqualFold(value, "C") || strings.EqualFold(value, "POSIX") {
+		return Identifier{}, fmt.Errorf("locale identifier %q names no language or region", value)
+	}
+
+	tag, err := language.Parse(value)
+	if err != nil {
+		return Identifier{}, fmt.Errorf("invalid locale identifier %q", value)
+	}
+
+	id := Identifier{Tag: tag.String()}
+	if base, confidence := tag.Base(); confidence == language.Exact && base.String() != "und" {
+		id.Language = base.String()
+	}
+	if script, confidence := tag.Script(); confidence == language.Exact {
+		id.Script = script.String()
+	}
+
+	region, confidence := tag.Region()
+	switch {
+	case confidence == language.Exact:
+		id.Region = region.String()
+		if !region.IsCountry() {
+			id.MacroRegion = true
+			id.RegionName = display.English.Regions().Name(region)
+		}
+	case confidence != language.No && id.Language != "" && region.IsCountry():
+		id.LikelyRegion = region.String()
+	}
+	return id, nil
+}
@@ -0,0 +1,78 @@
+package locale_test
+
+import (
+	"testing"
+
+	"country-iso-matcher/src/pkg/locale"
+)
+
+func TestParseIdentifier(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		expected      locale.Identifier
+		expectedError bool
+	}{
+		{
+			name:     "POSIX locale with codeset",
+			value:    "pt_BR.UTF-8",
+			expected: locale.Identifier{Tag: "pt-BR", Language: "pt", Region: "BR"},
+		},
+		{
+			name:     "POSIX modifier",
+			value:    "de_DE@euro",
+			expected: locale.Identifier{Tag: "de-DE", Language: "de", Region: "DE"},
+		},
+		{
+			name:     "script subtag",
+			value:    "zh-Hant-TW",
+			expected: locale.Identifier{Tag: "zh-Hant-TW", Language: "zh", Script: "Hant", Region: "TW"},
+		},
+		{
+			name:     "macro region",
+			value:    "es-419",
+			expected: locale.Identifier{Tag: "es-419", Language: "es", Region: "419", MacroRegion: true, RegionName: "Latin America"},
+		},
+		{
+			name:     "numeric country code",
+			value:    "pt-076",
+			expected: locale.Identifier{Tag: "pt-BR", Language: "pt", Region: "BR"},
+		},
+		{
+			name:     "likely region of a language",
+			value:    "de",
+			expected: locale.Identifier{Tag: "de", Language: "de", LikelyRegion: "DE"},
+		},
+		{
+			name:     "likely region of a script",
+			value:    "zh-Hant",
+			expected: locale.Identifier{Tag: "zh-Hant", Language: "zh", Script: "Hant", LikelyRegion: "TW"},
+		},
+		{
+			name:     "undetermined language",
+			value:    "und",
+			expected: locale.Identifier{Tag: "und"},
+		},
+		{name: "POSIX C locale", value: "C.UTF-8", expectedError: true},
+		{name: "invalid", value: "not a locale", expectedError: true},
+		{name: "empty", value: " ", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := locale.ParseIdentifier(tt.value)
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package locale
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// wildcard is what language.ParseAcceptLanguage returns for "*"
+var wildcard = language.Make("mul")
+
+// Preferences returns the requested languages as BCP 47 tags, most preferred first.
+// An explicit lang value (a single tag or a comma separated list) wins over the
+// Accept-Language header. Invalid header entries are ignored, an invalid lang is an error.
+func Preferences(lang, acceptLanguage string) ([]string, error) {
+	if lang = strings.TrimSpace(lang); lang != "" {
+		var preferences []string
+		for _, part := range strings.Split(lang, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			tag, err := language.Parse(part)
+			if err != nil {
+				return nil, fmt.Errorf("invalid language tag %q", part)
+			}
+			preferences = append(preferences, tag.String())
+		}
+		return preferences, nil
+	}
+
+	if acceptLanguage == "" {
+		return nil, nil
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil {
+		return nil, nil
+	}
+
+	preferences := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag == wildcard || tag.IsRoot() {
+			continue
+		}
+		preferences = append(preferences, tag.String())
+	}
+	return preferences, nil
+}
+
+// FallbackChain expands preferred tags into the ordered list of language keys to try,
+// following BCP 47 parents down to the base language (pt-BR → pt, es-MX → es-419 → es)
+// and ending with defaultLang. Duplicates are removed.
+func FallbackChain(preferred []string, defaultLang string) []string {
+	seen := make(map[string]bool)
+	var chain []string
+	add := func(key string) {
+		if key != "" && !seen[key] {
+			seen[key] = true
+			chain = append(chain, key)
+		}
+	}
+
+	for _, pref := range preferred {
+		tag, err := language.Parse(pref)
+		if err != nil {
+			continue
+		}
+
+		add(tag.String())
+		for parent := tag.Parent(); !parent.IsRoot(); parent = parent.Parent() {
+			add(parent.String())
+		}
+		if base, confidence := tag.Base(); confidence != language.No {
+			add(base.String())
+		}
+	}
+
+	add(defaultLang)
+	return chain
+}
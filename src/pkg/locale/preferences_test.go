@@ -0,0 +1,86 @@
+package locale_test
+
+import (
+	"reflect"
+	"testing"
+
+	"country-iso-matcher/src/pkg/locale"
+)
+
+func TestPreferences(t *testing.T) {
+	tests := []struct {
+		name           string
+		lang           string
+		acceptLanguage string
+		expected       []string
+		expectedError  bool
+	}{
+		{
+			name:     "explicit lang",
+			lang:     "fr",
+			expected: []string{"fr"},
+		},
+		{
+			name:           "lang wins over header",
+			lang:           "pt_BR",
+			acceptLanguage: "de",
+			expected:       []string{"pt-BR"},
+		},
+		{
+			name:           "header sorted by quality without wildcard",
+			acceptLanguage: "en;q=0.5, de-CH, *;q=0.1, fr;q=0.8",
+			expected:       []string{"de-CH", "fr", "en"},
+		},
+		{
+			name:           "malformed header is ignored",
+			acceptLanguage: "not a;;header",
+			expected:       nil,
+		},
+		{
+			name:          "invalid lang",
+			lang:          "!!",
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := locale.Preferences(tt.lang, tt.acceptLanguage)
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFallbackChain(t *testing.T) {
+	tests := []struct {
+		name      string
+		preferred []string
+		expected  []string
+	}{
+		{name: "regional variant", preferred: []string{"pt-BR"}, expected: []string{"pt-BR", "pt", "en"}},
+		{name: "macro region parent", preferred: []string{"es-MX"}, expected: []string{"es-MX", "es-419", "es", "en"}},
+		{name: "script subtag", preferred: []string{"zh-Hant-TW"}, expected: []string{"zh-Hant-TW", "zh-Hant", "zh", "en"}},
+		{name: "several preferences", preferred: []string{"de-AT", "fr"}, expected: []string{"de-AT", "de", "fr", "en"}},
+		{name: "no preferences", preferred: nil, expected: []string{"en"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := locale.FallbackChain(tt.preferred, "en")
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
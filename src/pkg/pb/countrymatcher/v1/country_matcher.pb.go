@@ -0,0 +1,670 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: countrymatcher/v1/country_matcher.proto
+
+package countrymatcherv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LookupRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Query string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Preferred BCP 47 language tags for localized_name, most preferred first.
+	Languages     []string `protobuf:"bytes,2,rep,name=languages,proto3" json:"languages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupRequest) Reset() {
+	*x = LookupRequest{}
+	mi := &file_countrymatcher_v1_country_matcher_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupRequest) ProtoMessage() {}
+
+func (x *LookupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_countrymatcher_v1_country_matcher_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupRequest.ProtoReflect.Descriptor instead.
+func (*LookupRequest) Descriptor() ([]byte, []int) {
+	return file_countrymatcher_v1_country_matcher_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LookupRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *LookupRequest) GetLanguages() []string {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+type GetCountryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Languages     []string               `protobuf:"bytes,2,rep,name=languages,proto3" json:"languages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCountryRequest) Reset() {
+	*x = GetCountryRequest{}
+	mi := &file_countrymatcher_v1_country_matcher_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCountryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCountryRequest) ProtoMessage() {}
+
+func (x *GetCountryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_countrymatcher_v1_country_matcher_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCountryRequest.ProtoReflect.Descriptor instead.
+func (*GetCountryRequest) Descriptor() ([]byte, []int) {
+	return file_countrymatcher_v1_country_matcher_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetCountryRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *GetCountryRequest) GetLanguages() []string {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+type Country struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	OfficialName string                 `protobuf:"bytes,1,opt,name=official_name,json=officialName,proto3" json:"official_name,omitempty"`
+	Iso2Code     string                 `protobuf:"bytes,2,opt,name=iso2_code,json=iso2Code,proto3" json:"iso2_code,omitempty"`
+	Iso3Code     string                 `protobuf:"bytes,3,opt,name=iso3_code,json=iso3Code,proto3" json:"iso3_code,omitempty"`
+	// Set when languages were requested.
+	LocalizedName string `protobuf:"bytes,4,opt,name=localized_name,json=localizedName,proto3" json:"localized_name,omitempty"`
+	Language      string `protobuf:"bytes,5,opt,name=language,proto3" json:"language,omitempty"`
+	// ISO 3166-1 numeric code with leading zeros, e.g. "076"; empty when the data has none.
+	NumericCode   string `protobuf:"bytes,6,opt,name=numeric_code,json=numericCode,proto3" json:"numeric_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Country) Reset() {
+	*x = Country{}
+	mi := &file_countrymatcher_v1_country_matcher_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Country) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Country) ProtoMessage() {}
+
+func (x *Country) ProtoReflect() protoreflect.Message {
+	mi := &file_countrymatcher_v1_country_matcher_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Country.ProtoReflect.Descriptor instead.
+func (*Country) Descriptor() ([]byte, []int) {
+	return file_countrymatcher_v1_country_matcher_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Country) GetOfficialName() string {
+	if x != nil {
+		return x.OfficialName
+	}
+	return ""
+}
+
+func (x *Country) GetIso2Code() string {
+	if x != nil {
+		return x.Iso2Code
+	}
+	return ""
+}
+
+func (x *Country) GetIso3Code() string {
+	if x != nil {
+		return x.Iso3Code
+	}
+	return ""
+}
+
+func (x *Country) GetLocalizedName() string {
+	if x != nil {
+		return x.LocalizedName
+	}
+	return ""
+}
+
+func (x *Country) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Country) GetNumericCode() string {
+	if x != nil {
+		return x.NumericCode
+	}
+	return ""
+}
+
+type LookupResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Query   string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Country *Country               `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"`
+	// exact, alias, code, fuzzy or historical.
+	MatchType string `protobuf:"bytes,3,opt,name=match_type,json=matchType,proto3" json:"match_type,omitempty"`
+	// Name the query was corrected to, for fuzzy matches only.
+	MatchedName string `protobuf:"bytes,4,opt,name=matched_name,json=matchedName,proto3" json:"matched_name,omitempty"`
+	Distance    int32  `protobuf:"varint,5,opt,name=distance,proto3" json:"distance,omitempty"`
+	// Set for historical matches: the withdrawn country the query named.
+	Historical    *HistoricalCountry `protobuf:"bytes,6,opt,name=historical,proto3" json:"historical,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupResponse) Reset() {
+	*x = LookupResponse{}
+	mi := &file_countrymatcher_v1_country_matcher_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupResponse) ProtoMessage() {}
+
+func (x *LookupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_countrymatcher_v1_country_matcher_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupResponse.ProtoReflect.Descriptor instead.
+func (*LookupResponse) Descriptor() ([]byte, []int) {
+	return file_countrymatcher_v1_country_matcher_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LookupResponse) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *LookupResponse) GetCountry() *Country {
+	if x != nil {
+		return x.Country
+	}
+	return nil
+}
+
+func (x *LookupResponse) GetMatchType() string {
+	if x != nil {
+		return x.MatchType
+	}
+	return ""
+}
+
+func (x *LookupResponse) GetMatchedName() string {
+	if x != nil {
+		return x.MatchedName
+	}
+	return ""
+}
+
+func (x *LookupResponse) GetDistance() int32 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *LookupResponse) GetHistorical() *HistoricalCountry {
+	if x != nil {
+		return x.Historical
+	}
+	return nil
+}
+
+// A country withdrawn from ISO 3166-1, as listed in ISO 3166-3.
+type HistoricalCountry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ISO 3166-3 four-letter code, e.g. "SUHH".
+	Code         string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	OfficialName string `protobuf:"bytes,2,opt,name=official_name,json=officialName,proto3" json:"official_name,omitempty"`
+	Iso2Code     string `protobuf:"bytes,3,opt,name=iso2_code,json=iso2Code,proto3" json:"iso2_code,omitempty"`
+	Iso3Code     string `protobuf:"bytes,4,opt,name=iso3_code,json=iso3Code,proto3" json:"iso3_code,omitempty"`
+	NumericCode  string `protobuf:"bytes,5,opt,name=numeric_code,json=numericCode,proto3" json:"numeric_code,omitempty"`
+	ValidFrom    string `protobuf:"bytes,6,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
+	ValidTo      string `protobuf:"bytes,7,opt,name=valid_to,json=validTo,proto3" json:"valid_to,omitempty"`
+	// Current countries that replaced it.
+	Successors    []*Country `protobuf:"bytes,8,rep,name=successors,proto3" json:"successors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoricalCountry) Reset() {
+	*x = HistoricalCountry{}
+	mi := &file_countrymatcher_v1_country_matcher_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoricalCountry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoricalCountry) ProtoMessage() {}
+
+func (x *HistoricalCountry) ProtoReflect() protoreflect.Message {
+	mi := &file_countrymatcher_v1_country_matcher_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoricalCountry.ProtoReflect.Descriptor instead.
+func (*HistoricalCountry) Descriptor() ([]byte, []int) {
+	return file_countrymatcher_v1_country_matcher_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *HistoricalCountry) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *HistoricalCountry) GetOfficialName() string {
+	if x != nil {
+		return x.OfficialName
+	}
+	return ""
+}
+
+func (x *HistoricalCountry) GetIso2Code() string {
+	if x != nil {
+		return x.Iso2Code
+	}
+	return ""
+}
+
+func (x *HistoricalCountry) GetIso3Code() string {
+	if x != nil {
+		return x.Iso3Code
+	}
+	return ""
+}
+
+func (x *HistoricalCountry) GetNumericCode() string {
+	if x != nil {
+		return x.NumericCode
+	}
+	return ""
+}
+
+func (x *HistoricalCountry) GetValidFrom() string {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return ""
+}
+
+func (x *HistoricalCountry) GetValidTo() string {
+	if x != nil {
+		return x.ValidTo
+	}
+	return ""
+}
+
+func (x *HistoricalCountry) GetSuccessors() []*Country {
+	if x != nil {
+		return x.Successors
+	}
+	return nil
+}
+
+type BatchLookupResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Position of the request in the stream, starting at 0.
+	Index int32  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Query string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	// ok, not_found, ambiguous, validation or error.
+	Status string          `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Result *LookupResponse `protobuf:"bytes,4,opt,name=result,proto3" json:"result,omitempty"`
+	Error  string          `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	// The countries an ambiguous query could refer to.
+	Candidates    []*Country `protobuf:"bytes,6,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchLookupResponse) Reset() {
+	*x = BatchLookupResponse{}
+	mi := &file_countrymatcher_v1_country_matcher_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchLookupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchLookupResponse) ProtoMessage() {}
+
+func (x *BatchLookupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_countrymatcher_v1_country_matcher_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchLookupResponse.ProtoReflect.Descriptor instead.
+func (*BatchLookupResponse) Descriptor() ([]byte, []int) {
+	return file_countrymatcher_v1_country_matcher_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BatchLookupResponse) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BatchLookupResponse) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *BatchLookupResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BatchLookupResponse) GetResult() *LookupResponse {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *BatchLookupResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BatchLookupResponse) GetCandidates() []*Country {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+// AmbiguousMatch is attached as a status detail to the FailedPrecondition error of an
+// ambiguous query, listing the countries it could refer to.
+type AmbiguousMatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Candidates    []*Country             `protobuf:"bytes,2,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AmbiguousMatch) Reset() {
+	*x = AmbiguousMatch{}
+	mi := &file_countrymatcher_v1_country_matcher_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AmbiguousMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AmbiguousMatch) ProtoMessage() {}
+
+func (x *AmbiguousMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_countrymatcher_v1_country_matcher_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AmbiguousMatch.ProtoReflect.Descriptor instead.
+func (*AmbiguousMatch) Descriptor() ([]byte, []int) {
+	return file_countrymatcher_v1_country_matcher_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AmbiguousMatch) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *AmbiguousMatch) GetCandidates() []*Country {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+var File_countrymatcher_v1_country_matcher_proto protoreflect.FileDescriptor
+
+const file_countrymatcher_v1_country_matcher_proto_rawDesc = "" +
+	"\n" +
+	"'countrymatcher/v1/country_matcher.proto\x12\x11countrymatcher.v1\"C\n" +
+	"\rLookupRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1c\n" +
+	"\tlanguages\x18\x02 \x03(\tR\tlanguages\"E\n" +
+	"\x11GetCountryRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x1c\n" +
+	"\tlanguages\x18\x02 \x03(\tR\tlanguages\"\xce\x01\n" +
+	"\aCountry\x12#\n" +
+	"\rofficial_name\x18\x01 \x01(\tR\fofficialName\x12\x1b\n" +
+	"\tiso2_code\x18\x02 \x01(\tR\biso2Code\x12\x1b\n" +
+	"\tiso3_code\x18\x03 \x01(\tR\biso3Code\x12%\n" +
+	"\x0elocalized_name\x18\x04 \x01(\tR\rlocalizedName\x12\x1a\n" +
+	"\blanguage\x18\x05 \x01(\tR\blanguage\x12!\n" +
+	"\fnumeric_code\x18\x06 \x01(\tR\vnumericCode\"\x80\x02\n" +
+	"\x0eLookupResponse\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x124\n" +
+	"\acountry\x18\x02 \x01(\v2\x1a.countrymatcher.v1.CountryR\acountry\x12\x1d\n" +
+	"\n" +
+	"match_type\x18\x03 \x01(\tR\tmatchType\x12!\n" +
+	"\fmatched_name\x18\x04 \x01(\tR\vmatchedName\x12\x1a\n" +
+	"\bdistance\x18\x05 \x01(\x05R\bdistance\x12D\n" +
+	"\n" +
+	"historical\x18\x06 \x01(\v2$.countrymatcher.v1.HistoricalCountryR\n" +
+	"historical\"\x9f\x02\n" +
+	"\x11HistoricalCountry\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12#\n" +
+	"\rofficial_name\x18\x02 \x01(\tR\fofficialName\x12\x1b\n" +
+	"\tiso2_code\x18\x03 \x01(\tR\biso2Code\x12\x1b\n" +
+	"\tiso3_code\x18\x04 \x01(\tR\biso3Code\x12!\n" +
+	"\fnumeric_code\x18\x05 \x01(\tR\vnumericCode\x12\x1d\n" +
+	"\n" +
+	"valid_from\x18\x06 \x01(\tR\tvalidFrom\x12\x19\n" +
+	"\bvalid_to\x18\a \x01(\tR\avalidTo\x12:\n" +
+	"\n" +
+	"successors\x18\b \x03(\v2\x1a.countrymatcher.v1.CountryR\n" +
+	"successors\"\xe6\x01\n" +
+	"\x13BatchLookupResponse\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x129\n" +
+	"\x06result\x18\x04 \x01(\v2!.countrymatcher.v1.LookupResponseR\x06result\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12:\n" +
+	"\n" +
+	"candidates\x18\x06 \x03(\v2\x1a.countrymatcher.v1.CountryR\n" +
+	"candidates\"b\n" +
+	"\x0eAmbiguousMatch\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12:\n" +
+	"\n" +
+	"candidates\x18\x02 \x03(\v2\x1a.countrymatcher.v1.CountryR\n" +
+	"candidates2\x93\x02\n" +
+	"\x0eCountryMatcher\x12M\n" +
+	"\x06Lookup\x12 .countrymatcher.v1.LookupRequest\x1a!.countrymatcher.v1.LookupResponse\x12[\n" +
+	"\vBatchLookup\x12 .countrymatcher.v1.LookupRequest\x1a&.countrymatcher.v1.BatchLookupResponse(\x010\x01\x12U\n" +
+	"\n" +
+	"GetCountry\x12$.countrymatcher.v1.GetCountryRequest\x1a!.countrymatcher.v1.LookupResponseBCZAcountry-iso-matcher/src/pkg/pb/countrymatcher/v1;countrymatcherv1b\x06proto3"
+
+var (
+	file_countrymatcher_v1_country_matcher_proto_rawDescOnce sync.Once
+	file_countrymatcher_v1_country_matcher_proto_rawDescData []byte
+)
+
+func file_countrymatcher_v1_country_matcher_proto_rawDescGZIP() []byte {
+	file_countrymatcher_v1_country_matcher_proto_rawDescOnce.Do(func() {
+		file_countrymatcher_v1_country_matcher_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_countrymatcher_v1_country_matcher_proto_rawDesc), len(file_countrymatcher_v1_country_matcher_proto_rawDesc)))
+	})
+	return file_countrymatcher_v1_country_matcher_proto_rawDescData
+}
+
+var file_countrymatcher_v1_country_matcher_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_countrymatcher_v1_country_matcher_proto_goTypes = []any{
+	(*LookupRequest)(nil),       // 0: countrymatcher.v1.LookupRequest
+	(*GetCountryRequest)(nil),   // 1: countrymatcher.v1.GetCountryRequest
+	(*Country)(nil),             // 2: countrymatcher.v1.Country
+	(*LookupResponse)(nil),      // 3: countrymatcher.v1.LookupResponse
+	(*HistoricalCountry)(nil),   // 4: countrymatcher.v1.HistoricalCountry
+	(*BatchLookupResponse)(nil), // 5: countrymatcher.v1.BatchLookupResponse
+	(*AmbiguousMatch)(nil),      // 6: countrymatcher.v1.AmbiguousMatch
+}
+var file_countrymatcher_v1_country_matcher_proto_depIdxs = []int32{
+	2, // 0: countrymatcher.v1.LookupResponse.country:type_name -> countrymatcher.v1.Country
+	4, // 1: countrymatcher.v1.LookupResponse.historical:type_name -> countrymatcher.v1.HistoricalCountry
+	2, // 2: countrymatcher.v1.HistoricalCountry.successors:type_name -> countrymatcher.v1.Country
+	3, // 3: countrymatcher.v1.BatchLookupResponse.result:type_name -> countrymatcher.v1.LookupResponse
+	2, // 4: countrymatcher.v1.BatchLookupResponse.candidates:type_name -> countrymatcher.v1.Country
+	2, // 5: countrymatcher.v1.AmbiguousMatch.candidates:type_name -> countrymatcher.v1.Country
+	0, // 6: countrymatcher.v1.CountryMatcher.Lookup:input_type -> countrymatcher.v1.LookupRequest
+	0, // 7: countrymatcher.v1.CountryMatcher.BatchLookup:input_type -> countrymatcher.v1.LookupRequest
+	1, // 8: countrymatcher.v1.CountryMatcher.GetCountry:input_type -> countrymatcher.v1.GetCountryRequest
+	3, // 9: countrymatcher.v1.CountryMatcher.Lookup:output_type -> countrymatcher.v1.LookupResponse
+	5, // 10: countrymatcher.v1.CountryMatcher.BatchLookup:output_type -> countrymatcher.v1.BatchLookupResponse
+	3, // 11: countrymatcher.v1.CountryMatcher.GetCountry:output_type -> countrymatcher.v1.LookupResponse
+	9, // [9:12] is the sub-list for method output_type
+	6, // [6:9] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_countrymatcher_v1_country_matcher_proto_init() }
+func file_countrymatcher_v1_country_matcher_proto_init() {
+	if File_countrymatcher_v1_country_matcher_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_countrymatcher_v1_country_matcher_proto_rawDesc), len(file_countrymatcher_v1_country_matcher_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_countrymatcher_v1_country_matcher_proto_goTypes,
+		DependencyIndexes: file_countrymatcher_v1_country_matcher_proto_depIdxs,
+		MessageInfos:      file_countrymatcher_v1_country_matcher_proto_msgTypes,
+	}.Build()
+	File_countrymatcher_v1_country_matcher_proto = out.File
+	file_countrymatcher_v1_country_matcher_proto_goTypes = nil
+	file_countrymatcher_v1_country_matcher_proto_depIdxs = nil
+}
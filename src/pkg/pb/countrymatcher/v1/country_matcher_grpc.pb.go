@@ -0,0 +1,204 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: countrymatcher/v1/country_matcher.proto
+
+package countrymatcherv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CountryMatcher_Lookup_FullMethodName      = "/countrymatcher.v1.CountryMatcher/Lookup"
+	CountryMatcher_BatchLookup_FullMethodName = "/countrymatcher.v1.CountryMatcher/BatchLookup"
+	CountryMatcher_GetCountry_FullMethodName  = "/countrymatcher.v1.CountryMatcher/GetCountry"
+)
+
+// CountryMatcherClient is the client API for CountryMatcher service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CountryMatcher resolves free-text country names to ISO 3166-1 codes.
+type CountryMatcherClient interface {
+	// Lookup resolves a single query.
+	Lookup(ctx context.Context, in *LookupRequest, opts ...grpc.CallOption) (*LookupResponse, error)
+	// BatchLookup resolves queries as they are sent and answers each one in order.
+	// Failed lookups are reported in the item status and never end the stream.
+	BatchLookup(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[LookupRequest, BatchLookupResponse], error)
+	// GetCountry returns a country by its ISO 3166-1 alpha-2 or alpha-3 code.
+	GetCountry(ctx context.Context, in *GetCountryRequest, opts ...grpc.CallOption) (*LookupResponse, error)
+}
+
+type countryMatcherClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCountryMatcherClient(cc grpc.ClientConnInterface) CountryMatcherClient {
+	return &countryMatcherClient{cc}
+}
+
+func (c *countryMatcherClient) Lookup(ctx context.Context, in *LookupRequest, opts ...grpc.CallOption) (*LookupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LookupResponse)
+	err := c.cc.Invoke(ctx, CountryMatcher_Lookup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *countryMatcherClient) BatchLookup(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[LookupRequest, BatchLookupResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CountryMatcher_ServiceDesc.Streams[0], CountryMatcher_BatchLookup_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[LookupRequest, BatchLookupResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CountryMatcher_BatchLookupClient = grpc.BidiStreamingClient[LookupRequest, BatchLookupResponse]
+
+func (c *countryMatcherClient) GetCountry(ctx context.Context, in *GetCountryRequest, opts ...grpc.CallOption) (*LookupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LookupResponse)
+	err := c.cc.Invoke(ctx, CountryMatcher_GetCountry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CountryMatcherServer is the server API for CountryMatcher service.
+// All implementations must embed UnimplementedCountryMatcherServer
+// for forward compatibility.
+//
+// CountryMatcher resolves free-text country names to ISO 3166-1 codes.
+type CountryMatcherServer interface {
+	// Lookup resolves a single query.
+	Lookup(context.Context, *LookupRequest) (*LookupResponse, error)
+	// BatchLookup resolves queries as they are sent and answers each one in order.
+	// Failed lookups are reported in the item status and never end the stream.
+	BatchLookup(grpc.BidiStreamingServer[LookupRequest, BatchLookupResponse]) error
+	// GetCountry returns a country by its ISO 3166-1 alpha-2 or alpha-3 code.
+	GetCountry(context.Context, *GetCountryRequest) (*LookupResponse, error)
+	mustEmbedUnimplementedCountryMatcherServer()
+}
+
+// UnimplementedCountryMatcherServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCountryMatcherServer struct{}
+
+func (UnimplementedCountryMatcherServer) Lookup(context.Context, *LookupRequest) (*LookupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Lookup not implemented")
+}
+func (UnimplementedCountryMatcherServer) BatchLookup(grpc.BidiStreamingServer[LookupRequest, BatchLookupResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method BatchLookup not implemented")
+}
+func (UnimplementedCountryMatcherServer) GetCountry(context.Context, *GetCountryRequest) (*LookupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCountry not implemented")
+}
+func (UnimplementedCountryMatcherServer) mustEmbedUnimplementedCountryMatcherServer() {}
+func (UnimplementedCountryMatcherServer) testEmbeddedByValue()                        {}
+
+// UnsafeCountryMatcherServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CountryMatcherServer will
+// result in compilation errors.
+type UnsafeCountryMatcherServer interface {
+	mustEmbedUnimplementedCountryMatcherServer()
+}
+
+func RegisterCountryMatcherServer(s grpc.ServiceRegistrar, srv CountryMatcherServer) {
+	// If the following call pancis, it indicates UnimplementedCountryMatcherServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CountryMatcher_ServiceDesc, srv)
+}
+
+func _CountryMatcher_Lookup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CountryMatcherServer).Lookup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CountryMatcher_Lookup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CountryMatcherServer).Lookup(ctx, req.(*LookupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CountryMatcher_BatchLookup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CountryMatcherServer).BatchLookup(&grpc.GenericServerStream[LookupRequest, BatchLookupResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CountryMatcher_BatchLookupServer = grpc.BidiStreamingServer[LookupRequest, BatchLookupResponse]
+
+func _CountryMatcher_GetCountry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCountryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CountryMatcherServer).GetCountry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CountryMatcher_GetCountry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CountryMatcherServer).GetCountry(ctx, req.(*GetCountryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CountryMatcher_ServiceDesc is the grpc.ServiceDesc for CountryMatcher service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CountryMatcher_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "countrymatcher.v1.CountryMatcher",
+	HandlerType: (*CountryMatcherServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Lookup",
+			Handler:    _CountryMatcher_Lookup_Handler,
+		},
+		{
+			MethodName: "GetCountry",
+			Handler:    _CountryMatcher_GetCountry_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BatchLookup",
+			Handler:       _CountryMatcher_BatchLookup_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "countrymatcher/v1/country_matcher.proto",
+}